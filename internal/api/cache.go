@@ -0,0 +1,73 @@
+package api
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NoCache disables the listing cache for clients created afterwards.
+// It backs the global --no-cache flag.
+var NoCache bool
+
+// cacheablePath matches the listing endpoints that interactive flows request
+// repeatedly: the store list and a store's repo list.
+var cacheablePath = regexp.MustCompile(`^/api/v1/stores(/[^/]+/repos)?$`)
+
+// responseCache is a per-process cache of GET responses keyed by path.
+// It is safe for concurrent use.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns the cached response for path if it has not expired.
+func (rc *responseCache) get(path string) ([]byte, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[path]
+	if !ok {
+		return nil, false
+	}
+	if rc.now().After(entry.expires) {
+		delete(rc.entries, path)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// set stores a response for path.
+func (rc *responseCache) set(path string, data []byte) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[path] = cacheEntry{data: data, expires: rc.now().Add(rc.ttl)}
+}
+
+// invalidate drops every entry for path, its parents and its children, so a
+// mutation of /stores/x clears both /stores and /stores/x/repos.
+func (rc *responseCache) invalidate(path string) {
+	path, _, _ = strings.Cut(path, "?")
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for key := range rc.entries {
+		if key == path || strings.HasPrefix(path, key+"/") || strings.HasPrefix(key, path+"/") {
+			delete(rc.entries, key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCachesListings(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			atomic.AddInt32(&hits, 1)
+			json.NewEncoder(w).Encode([]map[string]string{{"id": "s1", "slug": "alpha"}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "s2", "slug": "beta"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.ListStores(); err != nil {
+			t.Fatalf("ListStores() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&hits); got != 1 {
+		t.Errorf("server hits after repeated ListStores = %d, want 1", got)
+	}
+
+	// Creating a store must invalidate the store listing
+	if _, err := client.CreateStore("beta"); err != nil {
+		t.Fatalf("CreateStore() error = %v", err)
+	}
+	if _, err := client.ListStores(); err != nil {
+		t.Fatalf("ListStores() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 2 {
+		t.Errorf("server hits after CreateStore = %d, want 2", got)
+	}
+
+	// Expired entries are refetched
+	client.cache.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if _, err := client.ListStores(); err != nil {
+		t.Fatalf("ListStores() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&hits); got != 3 {
+		t.Errorf("server hits after expiry = %d, want 3", got)
+	}
+}
+
+func TestClientNoCache(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	NoCache = true
+	defer func() { NoCache = false }()
+
+	client := NewClient("https://example.com", "test-key")
+	if client.cache != nil {
+		t.Error("NewClient() with NoCache set has a cache")
+	}
+}
+
+func TestResponseCacheInvalidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutation string
+		want     []string // keys that survive
+	}{
+		{name: "create store", mutation: "/api/v1/stores", want: []string{}},
+		{name: "delete store", mutation: "/api/v1/stores/a", want: []string{"/api/v1/stores/b/repos"}},
+		{name: "delete repo", mutation: "/api/v1/stores/b/repos/x", want: []string{"/api/v1/stores/a/repos"}},
+		{name: "unrelated", mutation: "/api/v1/claims", want: []string{"/api/v1/stores", "/api/v1/stores/a/repos", "/api/v1/stores/b/repos"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc := newResponseCache(time.Minute)
+			for _, k := range []string{"/api/v1/stores", "/api/v1/stores/a/repos", "/api/v1/stores/b/repos"} {
+				rc.set(k, []byte("[]"))
+			}
+			rc.invalidate(tt.mutation)
+			if len(rc.entries) != len(tt.want) {
+				t.Errorf("invalidate(%q) left %d entries, want %v", tt.mutation, len(rc.entries), tt.want)
+			}
+			for _, k := range tt.want {
+				if _, ok := rc.get(k); !ok {
+					t.Errorf("invalidate(%q) removed %q", tt.mutation, k)
+				}
+			}
+		})
+	}
+}
@@ -3,14 +3,22 @@ package api
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/log"
 	"github.com/morrisclay/scraps-cli/internal/model"
 )
 
@@ -18,25 +26,64 @@ import (
 type Client struct {
 	host       string
 	apiKey     string
+	authSource string
 	httpClient *http.Client
+	cache      *responseCache // nil when caching is disabled
+}
+
+// HostOverride, when set, replaces the configured default host for clients
+// created without an explicit host. It backs the global --host flag.
+var HostOverride string
+
+// DefaultHost returns HostOverride if set, otherwise the configured host.
+func DefaultHost() string {
+	if HostOverride != "" {
+		return HostOverride
+	}
+	return config.GetHost()
 }
 
 // NewClient creates a new API client.
 func NewClient(host, apiKey string) *Client {
 	if host == "" {
-		host = config.GetHost()
+		host = DefaultHost()
 	}
-	return &Client{
+	// URL builders below rely on the host carrying a scheme and no trailing slash
+	if normalized, err := config.NormalizeHost(host); err == nil {
+		host = normalized
+	}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = &config.Config{RequestTimeoutSeconds: config.DefaultRequestTimeoutSeconds}
+	}
+
+	// The timeout covers connecting and waiting for headers only, so large
+	// file bodies are never cut off mid-transfer.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = cfg.RequestTimeout()
+
+	client := &Client{
 		host:       host,
 		apiKey:     apiKey,
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Transport: transport},
+	}
+	if ttl := cfg.CacheTTL(); !NoCache && ttl > 0 {
+		client.cache = newResponseCache(ttl)
 	}
+	return client
 }
 
 // NewClientFromConfig creates a client using stored credentials.
+// SCRAPS_API_KEY, when set, is used without consulting credentials.json.
 func NewClientFromConfig(host string) (*Client, error) {
 	if host == "" {
-		host = config.GetHost()
+		host = DefaultHost()
+	}
+
+	if apiKey := config.EnvAPIKey(); apiKey != "" {
+		client := NewClient(host, apiKey)
+		client.authSource = config.CredentialSourceEnv
+		return client, nil
 	}
 
 	cred, err := config.GetCredential(host)
@@ -44,10 +91,12 @@ func NewClientFromConfig(host string) (*Client, error) {
 		return nil, err
 	}
 	if cred == nil {
-		return nil, fmt.Errorf("not logged in to %s", host)
+		return nil, fmt.Errorf("%w to %s", ErrNotLoggedIn, host)
 	}
 
-	return NewClient(host, cred.APIKey), nil
+	client := NewClient(host, cred.APIKey)
+	client.authSource = cred.Source
+	return client, nil
 }
 
 // Host returns the API host.
@@ -60,6 +109,12 @@ func (c *Client) APIKey() string {
 	return c.apiKey
 }
 
+// AuthSource returns where the API key came from (config.CredentialSourceEnv
+// or config.CredentialSourceFile), or "" if the client was built directly.
+func (c *Client) AuthSource() string {
+	return c.authSource
+}
+
 // HasAuth returns true if the client has authentication.
 func (c *Client) HasAuth() bool {
 	return c.apiKey != ""
@@ -67,10 +122,49 @@ func (c *Client) HasAuth() bool {
 
 // request performs an HTTP request.
 func (c *Client) request(method, path string, body any) ([]byte, error) {
+	cacheKey := ""
+	if c.cache != nil {
+		if method != http.MethodGet {
+			c.cache.invalidate(path)
+		} else if cacheablePath.MatchString(path) {
+			if data, ok := c.cache.get(path); ok {
+				return data, nil
+			}
+			cacheKey = path
+		}
+	}
+
+	resp, err := c.requestFull(method, path, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cacheKey != "" {
+		c.cache.set(cacheKey, resp.Body)
+	}
+	return resp.Body, nil
+}
+
+// response is a fully read HTTP response.
+type response struct {
+	Body       []byte
+	Header     http.Header
+	StatusCode int
+}
+
+// requestFull performs an HTTP request with optional extra request headers
+// and returns the whole response. Statuses below 400, such as 304 Not
+// Modified, are not errors. For API errors the response is returned
+// alongside the *APIError so callers can still inspect its headers.
+func (c *Client) requestFull(method, path string, body any, header http.Header) (*response, error) {
+	// Keep any query string out of JoinPath, which would escape the "?"
+	path, query, _ := strings.Cut(path, "?")
 	u, err := url.JoinPath(c.host, path)
 	if err != nil {
 		return nil, err
 	}
+	if query != "" {
+		u += "?" + query
+	}
 
 	var bodyReader io.Reader
 	if body != nil {
@@ -86,21 +180,32 @@ func (c *Client) request(method, path string, body any) ([]byte, error) {
 		return nil, err
 	}
 
+	for name, values := range header {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
 	req.Header.Set("Content-Type", "application/json")
 	if c.apiKey != "" {
 		req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	start := time.Now()
+	httpResp, err := c.httpClient.Do(req)
 	if err != nil {
+		traceRequest(method, u, 0, nil, time.Since(start))
+		log.Warn("api request failed", "method", method, "url", u, "error", err)
 		return nil, err
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
+	traceRequest(method, u, httpResp.StatusCode, httpResp.Header, time.Since(start))
+	log.Debug("api request", "method", method, "url", u, "status", httpResp.StatusCode, "duration", time.Since(start))
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := io.ReadAll(httpResp.Body)
 	if err != nil {
 		return nil, err
 	}
+	resp := &response{Body: respBody, Header: httpResp.Header, StatusCode: httpResp.StatusCode}
 
 	if resp.StatusCode >= 400 {
 		var errResp struct {
@@ -115,10 +220,38 @@ func (c *Client) request(method, path string, body any) ([]byte, error) {
 				msg = errResp.Error
 			}
 		}
-		return nil, &APIError{StatusCode: resp.StatusCode, Message: msg}
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: msg, RequestID: resp.Header.Get("X-Request-ID")}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			apiErr.RateLimitReset = parseRateLimitReset(resp.Header, time.Now())
+			apiErr.RetryAfter = parseRetryAfter(resp.Header, time.Now())
+		}
+		log.Warn("api error", "method", method, "url", u, "status", resp.StatusCode, "message", msg, "request_id", apiErr.RequestID)
+		return resp, apiErr
 	}
 
-	return respBody, nil
+	return resp, nil
+}
+
+// Trace, when set, receives one line per HTTP request with its method, URL,
+// status and duration. It backs the global --verbose flag. Request headers,
+// and so the API key, are never written.
+var Trace io.Writer
+
+// traceRequest writes a request summary to Trace. A zero status means the
+// request failed before a response arrived.
+func traceRequest(method, u string, status int, header http.Header, elapsed time.Duration) {
+	if Trace == nil {
+		return
+	}
+	result := "failed"
+	if status != 0 {
+		result = strconv.Itoa(status)
+	}
+	line := fmt.Sprintf("%s %s %s (%s)", method, u, result, elapsed.Round(time.Millisecond))
+	if remaining := header.Get("X-RateLimit-Remaining"); remaining != "" {
+		line += fmt.Sprintf(" [rate limit remaining: %s]", remaining)
+	}
+	fmt.Fprintln(Trace, line)
 }
 
 // Get performs a GET request.
@@ -291,6 +424,36 @@ func (c *Client) CreateStore(slug string) (*model.Store, error) {
 	return &store, nil
 }
 
+// UpdateStore changes a store's slug.
+func (c *Client) UpdateStore(slug, newSlug string) (*model.Store, error) {
+	data, err := c.request("PATCH", "/api/v1/stores/"+url.PathEscape(slug), map[string]string{"slug": newSlug})
+	if err != nil {
+		return nil, err
+	}
+
+	// Try direct store object first
+	var store model.Store
+	if err := json.Unmarshal(data, &store); err == nil && store.ID != "" {
+		return &store, nil
+	}
+
+	// Try wrapped format {"store": {...}}
+	var wrapper struct {
+		Store model.Store `json:"store"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err == nil && wrapper.Store.ID != "" {
+		return &wrapper.Store, nil
+	}
+	return &model.Store{Slug: newSlug}, nil
+}
+
+// TransferStore makes another user the owner of a store.
+func (c *Client) TransferStore(slug, newOwnerUsername string) error {
+	return c.Post("/api/v1/stores/"+url.PathEscape(slug)+"/transfer", map[string]string{
+		"username": newOwnerUsername,
+	}, nil)
+}
+
 // DeleteStore deletes a store.
 func (c *Client) DeleteStore(slug string) error {
 	return c.Delete("/api/v1/stores/"+url.PathEscape(slug), nil)
@@ -363,21 +526,49 @@ func (c *Client) ListRepos(store string) ([]model.Repository, error) {
 	return wrapper.Repos, nil
 }
 
-// ListAllRepos returns all repos across all stores.
+// listAllReposConcurrency bounds the number of ListRepos requests that
+// ListAllRepos has in flight at once.
+const listAllReposConcurrency = 8
+
+// ListAllRepos returns all repos across all stores, sorted by store and then
+// name. Stores are queried concurrently; stores whose repos cannot be listed
+// are skipped.
 func (c *Client) ListAllRepos() ([]model.Repository, error) {
 	stores, err := c.ListStores()
 	if err != nil {
 		return nil, err
 	}
 
+	// Each worker writes only its own slot, so no locking is needed
+	perStore := make([][]model.Repository, len(stores))
+	sem := make(chan struct{}, listAllReposConcurrency)
+	var wg sync.WaitGroup
+	for i, store := range stores {
+		wg.Add(1)
+		go func(i int, slug string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			repos, err := c.ListRepos(slug)
+			if err != nil {
+				return // Skip stores we can't access
+			}
+			perStore[i] = repos
+		}(i, store.Slug)
+	}
+	wg.Wait()
+
 	var allRepos []model.Repository
-	for _, store := range stores {
-		repos, err := c.ListRepos(store.Slug)
-		if err != nil {
-			continue // Skip stores we can't access
-		}
+	for _, repos := range perStore {
 		allRepos = append(allRepos, repos...)
 	}
+	sort.SliceStable(allRepos, func(i, j int) bool {
+		if allRepos[i].Store != allRepos[j].Store {
+			return allRepos[i].Store < allRepos[j].Store
+		}
+		return allRepos[i].Name < allRepos[j].Name
+	})
 	return allRepos, nil
 }
 
@@ -425,6 +616,14 @@ func (c *Client) DeleteRepo(store, name string) error {
 	return c.Delete("/api/v1/stores/"+url.PathEscape(store)+"/repos/"+url.PathEscape(name), nil)
 }
 
+// SetRepoArchived archives or unarchives a repository. Archived
+// repositories are read-only.
+func (c *Client) SetRepoArchived(store, repo string, archived bool) error {
+	return c.Patch("/api/v1/stores/"+url.PathEscape(store)+"/repos/"+url.PathEscape(repo), map[string]bool{
+		"archived": archived,
+	}, nil)
+}
+
 // ListCollaborators returns collaborators of a repository.
 func (c *Client) ListCollaborators(store, repo string) ([]model.Collaborator, error) {
 	var collabs []model.Collaborator
@@ -449,6 +648,14 @@ func (c *Client) AddCollaborator(store, repo, username, role string) (*model.Col
 	return &collab, nil
 }
 
+// UpdateCollaborator updates a collaborator's role.
+func (c *Client) UpdateCollaborator(store, repo, collabID, role string) error {
+	path := "/api/v1/stores/" + url.PathEscape(store) + "/repos/" + url.PathEscape(repo) + "/collaborators/" + url.PathEscape(collabID)
+	return c.Patch(path, map[string]string{
+		"role": role,
+	}, nil)
+}
+
 // RemoveCollaborator removes a collaborator from a repository.
 func (c *Client) RemoveCollaborator(store, repo, collabID string) error {
 	path := "/api/v1/stores/" + url.PathEscape(store) + "/repos/" + url.PathEscape(repo) + "/collaborators/" + url.PathEscape(collabID)
@@ -457,7 +664,8 @@ func (c *Client) RemoveCollaborator(store, repo, collabID string) error {
 
 // --- File endpoints ---
 
-// GetFileTree returns the file tree for a path.
+// GetFileTree returns the file tree for a path. branch may also be a
+// commit SHA to list the tree as of that commit.
 func (c *Client) GetFileTree(store, repo, branch, path string) ([]model.FileTreeEntry, error) {
 	var entries []model.FileTreeEntry
 	apiPath := "/api/v1/stores/" + url.PathEscape(store) + "/repos/" + url.PathEscape(repo) + "/tree/" + url.PathEscape(branch)
@@ -470,23 +678,140 @@ func (c *Client) GetFileTree(store, repo, branch, path string) ([]model.FileTree
 	return entries, nil
 }
 
-// GetFileContent returns the content of a file.
+// GetFileContent returns the content of a file. branch may also be a
+// commit SHA to read the file as of that commit.
 func (c *Client) GetFileContent(store, repo, branch, path string) ([]byte, error) {
 	apiPath := "/api/v1/stores/" + url.PathEscape(store) + "/repos/" + url.PathEscape(repo) + "/files/" + url.PathEscape(branch) + "/" + path
 	return c.GetRaw(apiPath)
 }
 
-// GetLog returns the commit log for a branch.
+// GetFileContentIfModified fetches a file unless it still matches etag. It
+// returns the content and the file's current ETag; notModified is true (and
+// content nil) when the server answered 304. An empty etag always fetches.
+func (c *Client) GetFileContentIfModified(store, repo, branch, path, etag string) (content []byte, newETag string, notModified bool, err error) {
+	apiPath := "/api/v1/stores/" + url.PathEscape(store) + "/repos/" + url.PathEscape(repo) + "/files/" + url.PathEscape(branch) + "/" + path
+	header := http.Header{}
+	if etag != "" {
+		header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.requestFull("GET", apiPath, nil, header)
+	if err != nil {
+		return nil, "", false, err
+	}
+	newETag = resp.Header.Get("ETag")
+	if resp.StatusCode == http.StatusNotModified {
+		if newETag == "" {
+			newETag = etag
+		}
+		return nil, newETag, true, nil
+	}
+	return resp.Body, newETag, false, nil
+}
+
+// PutFileContent writes a file to a branch, creating a commit with the given message.
+func (c *Client) PutFileContent(store, repo, branch, path string, content []byte, message string) error {
+	apiPath := "/api/v1/stores/" + url.PathEscape(store) + "/repos/" + url.PathEscape(repo) + "/files/" + url.PathEscape(branch) + "/" + path
+	return c.Put(apiPath, map[string]string{
+		"content":  base64.StdEncoding.EncodeToString(content),
+		"encoding": "base64",
+		"message":  message,
+	}, nil)
+}
+
+// DeleteFile deletes a file from a branch, creating a commit with the given message.
+func (c *Client) DeleteFile(store, repo, branch, path, message string) error {
+	apiPath := "/api/v1/stores/" + url.PathEscape(store) + "/repos/" + url.PathEscape(repo) + "/files/" + url.PathEscape(branch) + "/" + path
+	return c.Delete(apiPath, map[string]string{"message": message})
+}
+
+// ListBranches returns the branches of a repository.
+func (c *Client) ListBranches(store, repo string) ([]model.Branch, error) {
+	path := fmt.Sprintf("/api/v1/stores/%s/repos/%s/branches",
+		url.PathEscape(store), url.PathEscape(repo))
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try array first
+	var branches []model.Branch
+	if err := json.Unmarshal(data, &branches); err == nil {
+		return branches, nil
+	}
+
+	// Try array of branch names
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		branches = make([]model.Branch, len(names))
+		for i, name := range names {
+			branches[i] = model.Branch{Name: name}
+		}
+		return branches, nil
+	}
+
+	// Try object with branches key
+	var wrapper struct {
+		Branches []model.Branch `json:"branches"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+	return wrapper.Branches, nil
+}
+
+// GetLog returns the commit log for a branch, or for the history leading
+// up to a commit if branch is a commit SHA.
 func (c *Client) GetLog(store, repo, branch string, limit int) ([]model.Commit, error) {
+	return c.GetLogFiltered(store, repo, branch, limit, time.Time{}, time.Time{})
+}
+
+// GetLogFiltered returns the commit log for a branch, restricted to commits
+// between since and until. Zero times are not sent. Servers that ignore the
+// parameters return the unfiltered log, so callers should filter as well.
+func (c *Client) GetLogFiltered(store, repo, branch string, limit int, since, until time.Time) ([]model.Commit, error) {
 	var commits []model.Commit
-	path := fmt.Sprintf("/api/v1/stores/%s/repos/%s/log/%s?limit=%d",
-		url.PathEscape(store), url.PathEscape(repo), url.PathEscape(branch), limit)
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		query.Set("until", until.UTC().Format(time.RFC3339))
+	}
+	path := fmt.Sprintf("/api/v1/stores/%s/repos/%s/log/%s?%s",
+		url.PathEscape(store), url.PathEscape(repo), url.PathEscape(branch), query.Encode())
 	if err := c.Get(path, &commits); err != nil {
 		return nil, err
 	}
 	return commits, nil
 }
 
+// GetCommit returns a single commit with its changed files.
+func (c *Client) GetCommit(store, repo, sha string) (*model.CommitDetail, error) {
+	path := fmt.Sprintf("/api/v1/stores/%s/repos/%s/commits/%s",
+		url.PathEscape(store), url.PathEscape(repo), url.PathEscape(sha))
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try direct commit object first
+	var commit model.CommitDetail
+	if err := json.Unmarshal(data, &commit); err == nil && commit.SHA != "" {
+		return &commit, nil
+	}
+
+	// Try wrapped format {"commit": {...}}
+	var wrapper struct {
+		Commit model.CommitDetail `json:"commit"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+	return &wrapper.Commit, nil
+}
+
 // --- Token endpoints ---
 
 // ListAPIKeys returns all API keys.
@@ -512,6 +837,32 @@ func (c *Client) ListAPIKeys() ([]model.APIKey, error) {
 	return wrapper.APIKeys, nil
 }
 
+// GetAPIKey returns a single API key by ID.
+func (c *Client) GetAPIKey(id string) (*model.APIKey, error) {
+	data, err := c.request("GET", "/api/v1/api-keys/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try direct key object first
+	var key model.APIKey
+	if err := json.Unmarshal(data, &key); err == nil && key.ID != "" {
+		return &key, nil
+	}
+
+	// Try wrapped format {"api_key": {...}}
+	var wrapper struct {
+		APIKey model.APIKey `json:"api_key"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+	if wrapper.APIKey.ID == "" {
+		return nil, fmt.Errorf("unexpected API key response: missing id")
+	}
+	return &wrapper.APIKey, nil
+}
+
 // CreateAPIKey creates a new API key.
 func (c *Client) CreateAPIKey(label string) (*model.TokenCreateResponse, error) {
 	body := map[string]string{}
@@ -574,6 +925,32 @@ func (c *Client) ListScopedTokens() ([]model.ScopedToken, error) {
 	return wrapper.ScopedTokens, nil
 }
 
+// GetScopedToken returns a single scoped token by ID.
+func (c *Client) GetScopedToken(id string) (*model.ScopedToken, error) {
+	data, err := c.request("GET", "/api/v1/scoped-tokens/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try direct token object first
+	var token model.ScopedToken
+	if err := json.Unmarshal(data, &token); err == nil && token.ID != "" {
+		return &token, nil
+	}
+
+	// Try wrapped format {"scoped_token": {...}}
+	var wrapper struct {
+		ScopedToken model.ScopedToken `json:"scoped_token"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+	if wrapper.ScopedToken.ID == "" {
+		return nil, fmt.Errorf("unexpected scoped token response: missing id")
+	}
+	return &wrapper.ScopedToken, nil
+}
+
 // CreateScopedToken creates a new scoped token.
 func (c *Client) CreateScopedToken(label, storeID string, repos, permissions []string, expiresInDays int) (*model.TokenCreateResponse, error) {
 	var resp model.TokenCreateResponse
@@ -616,6 +993,31 @@ func (c *Client) Claim(store, repo, branch string, req model.ClaimRequest) (*mod
 	return &resp, nil
 }
 
+// ListClaims returns the active claims on a branch.
+func (c *Client) ListClaims(store, repo, branch string) ([]model.Claim, error) {
+	path := fmt.Sprintf("/stores/%s/repos/%s/branches/%s/coordinate/claim",
+		url.PathEscape(store), url.PathEscape(repo), url.PathEscape(branch))
+	data, err := c.request("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try array first
+	var claims []model.Claim
+	if err := json.Unmarshal(data, &claims); err == nil {
+		return claims, nil
+	}
+
+	// Try object with claims key
+	var wrapper struct {
+		Claims []model.Claim `json:"claims"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return nil, err
+	}
+	return wrapper.Claims, nil
+}
+
 // Release releases claimed file patterns.
 func (c *Client) Release(store, repo, branch string, req model.ReleaseRequest) error {
 	path := fmt.Sprintf("/stores/%s/repos/%s/branches/%s/coordinate/claim",
@@ -623,6 +1025,35 @@ func (c *Client) Release(store, repo, branch string, req model.ReleaseRequest) e
 	return c.Delete(path, req)
 }
 
+// ReleaseAll releases every pattern agentID holds on a branch and returns
+// the patterns released, which is empty if the agent held none.
+func (c *Client) ReleaseAll(store, repo, branch, agentID string) ([]string, error) {
+	claims, err := c.ListClaims(store, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []string{}
+	for _, claim := range claims {
+		if claim.AgentID != agentID {
+			continue
+		}
+		for _, p := range claim.Patterns {
+			if !slices.Contains(patterns, p) {
+				patterns = append(patterns, p)
+			}
+		}
+	}
+	if len(patterns) == 0 {
+		return patterns, nil
+	}
+
+	if err := c.Release(store, repo, branch, model.ReleaseRequest{AgentID: agentID, Patterns: patterns}); err != nil {
+		return nil, err
+	}
+	return patterns, nil
+}
+
 // --- Helper functions ---
 
 // GetCloneURL returns the git clone URL for a repository.
@@ -641,7 +1072,24 @@ func (c *Client) GetCloneURL(store, repo string) string {
 		protocol, c.apiKey, host, url.PathEscape(store), url.PathEscape(repo))
 }
 
+// ParseCloneURL extracts the store and repository from a clone URL such as
+// the ones GetCloneURL builds. Embedded credentials and a trailing ".git"
+// are ignored.
+func ParseCloneURL(rawURL string) (store, repo string, err error) {
+	u, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return "", "", err
+	}
+	path := strings.TrimSuffix(strings.TrimSuffix(u.EscapedPath(), "/"), ".git")
+	store, repo, ok := parseStoreRepoPath(cloneURLPath, path)
+	if !ok {
+		return "", "", fmt.Errorf("not a clone URL: expected .../stores/<store>/repos/<repo>, got %q", u.Redacted())
+	}
+	return store, repo, nil
+}
+
 // BuildWebSocketURL returns the WebSocket URL for watching a repository.
+// The API key is not included; ws.Client sends it in a header.
 func (c *Client) BuildWebSocketURL(store, repo string, branch string) string {
 	host := c.host
 	protocol := "wss"
@@ -652,36 +1100,24 @@ func (c *Client) BuildWebSocketURL(store, repo string, branch string) string {
 		protocol = "ws"
 	}
 
-	wsURL := fmt.Sprintf("%s://%s/stores/%s/repos/%s/ws?token=%s",
-		protocol, host, url.PathEscape(store), url.PathEscape(repo), url.QueryEscape(c.apiKey))
+	wsURL := fmt.Sprintf("%s://%s/stores/%s/repos/%s/ws",
+		protocol, host, url.PathEscape(store), url.PathEscape(repo))
 
 	if branch != "" {
-		wsURL += "&branch=" + url.QueryEscape(branch)
+		wsURL += "?branch=" + url.QueryEscape(branch)
 	}
 
 	return wsURL
 }
 
-// BuildClaimsWebSocketURL returns the WebSocket URL for watching claims.
-// Deprecated: Use BuildStreamURL instead.
-func (c *Client) BuildClaimsWebSocketURL(store, repo, branch string) string {
-	host := c.host
-	protocol := "wss"
-	if strings.HasPrefix(host, "https://") {
-		host = strings.TrimPrefix(host, "https://")
-	} else if strings.HasPrefix(host, "http://") {
-		host = strings.TrimPrefix(host, "http://")
-		protocol = "ws"
-	}
-
-	return fmt.Sprintf("%s://%s/stores/%s/repos/%s/branches/%s/coordinate/tail?token=%s",
-		protocol, host, url.PathEscape(store), url.PathEscape(repo), url.PathEscape(branch), url.QueryEscape(c.apiKey))
-}
-
 // StreamOptions configures the event stream URL.
 type StreamOptions struct {
 	Branch string
 	Path   string
+	// EventID resumes the stream after the event with this ID. It is sent
+	// as a query parameter, which stays the same when a client re-dials the
+	// URL, so reconnecting clients should send Last-Event-ID instead.
+	EventID string
 }
 
 // BuildStreamURL returns the URL for the event streaming endpoint.
@@ -700,6 +1136,9 @@ func (c *Client) BuildStreamURL(store, repo string, opts *StreamOptions) string
 	if opts.Path != "" {
 		params.Set("path", opts.Path)
 	}
+	if opts.EventID != "" {
+		params.Set("last_event_id", opts.EventID)
+	}
 
 	if len(params) > 0 {
 		return baseURL + "?" + params.Encode()
@@ -707,6 +1146,68 @@ func (c *Client) BuildStreamURL(store, repo string, opts *StreamOptions) string
 	return baseURL
 }
 
+// ParseStreamURL is the inverse of BuildStreamURL. It returns the store,
+// repository and any stream options set in the query string.
+func ParseStreamURL(rawURL string) (store, repo string, opts *StreamOptions, err error) {
+	u, err := parseHTTPURL(rawURL)
+	if err != nil {
+		return "", "", nil, err
+	}
+	store, repo, ok := parseStoreRepoPath(streamURLPath, u.EscapedPath())
+	if !ok {
+		return "", "", nil, fmt.Errorf("not a stream URL: expected .../api/v1/stores/<store>/repos/<repo>/streams/events/live, got %q", u.Redacted())
+	}
+
+	q := u.Query()
+	opts = &StreamOptions{
+		Branch:  q.Get("branch"),
+		Path:    q.Get("path"),
+		EventID: q.Get("last_event_id"),
+	}
+	return store, repo, opts, nil
+}
+
+// parseHTTPURL parses an http or https URL. Errors never echo the input,
+// which may carry an API key.
+func parseHTTPURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme %q: expected http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid URL: missing host")
+	}
+	return u, nil
+}
+
+// Escaped URL paths that ParseCloneURL and ParseStreamURL accept. Either
+// may sit below a base path.
+var (
+	cloneURLPath  = regexp.MustCompile(`/stores/([^/]+)/repos/([^/]+)$`)
+	streamURLPath = regexp.MustCompile(`/api/v1/stores/([^/]+)/repos/([^/]+)/streams/events/live$`)
+)
+
+// parseStoreRepoPath matches an escaped URL path against re and returns the
+// unescaped store and repo it captures.
+func parseStoreRepoPath(re *regexp.Regexp, path string) (store, repo string, ok bool) {
+	m := re.FindStringSubmatch(path)
+	if m == nil {
+		return "", "", false
+	}
+	store, err := url.PathUnescape(m[1])
+	if err != nil {
+		return "", "", false
+	}
+	repo, err = url.PathUnescape(m[2])
+	if err != nil {
+		return "", "", false
+	}
+	return store, repo, true
+}
+
 // StreamEvent represents an event from the stream.
 type StreamEvent struct {
 	Type      string                 `json:"type"`
@@ -720,34 +1221,22 @@ type StreamEventsResponse struct {
 	Events []map[string]interface{} `json:"events"`
 }
 
-// GetRecentStreamEvents fetches recent events from the stream (non-live).
-func (c *Client) GetRecentStreamEvents(store, repo string, limit int) ([]map[string]interface{}, error) {
-	// Build URL manually to avoid JoinPath mangling the query string
-	fullURL := fmt.Sprintf("%s/api/v1/stores/%s/repos/%s/streams/events?limit=%d",
-		c.host, url.PathEscape(store), url.PathEscape(repo), limit)
-
-	req, err := http.NewRequest("GET", fullURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+// GetRecentStreamEvents fetches up to limit recent events from the stream
+// (non-live), newest first. A non-zero since asks for events after that
+// time only; servers that ignore it return the unfiltered list, so callers
+// should filter as well.
+func (c *Client) GetRecentStreamEvents(store, repo string, limit int, since time.Time) ([]map[string]interface{}, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if !since.IsZero() {
+		query.Set("since", since.UTC().Format(time.RFC3339))
 	}
+	path := fmt.Sprintf("/api/v1/stores/%s/repos/%s/streams/events?%s",
+		url.PathEscape(store), url.PathEscape(repo), query.Encode())
 
 	var response StreamEventsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+	if err := c.Get(path, &response); err != nil {
 		return nil, err
 	}
-
 	return response.Events, nil
 }
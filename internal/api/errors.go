@@ -1,18 +1,54 @@
 package api
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrNotLoggedIn is returned when no API key is available for the host.
+var ErrNotLoggedIn = errors.New("not logged in")
 
 // APIError represents an error returned by the API.
 type APIError struct {
 	StatusCode int
 	Message    string
+
+	// RateLimitReset is how long until the rate limit resets, taken from
+	// X-RateLimit-Reset. Zero when the server did not say.
+	RateLimitReset time.Duration
+
+	// RetryAfter is how long the server asked us to wait before retrying,
+	// taken from Retry-After. Zero when the header was absent.
+	RetryAfter time.Duration
+
+	// RequestID is the server's X-Request-ID for the failed request, for
+	// matching it up with server logs. Empty when the header was absent.
+	RequestID string
 }
 
 // Error implements the error interface.
 func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s (request id: %s)", e.message(), e.RequestID)
+	}
+	return e.message()
+}
+
+// message describes the error without the request ID.
+func (e *APIError) message() string {
 	if e.StatusCode == 401 {
 		return "You are not logged in or don't have necessary permissions. Run 'scraps login' and try again."
 	}
+	if e.StatusCode == 429 {
+		// The body of a 429 is rarely useful, so describe it ourselves
+		if e.RateLimitReset > 0 {
+			return fmt.Sprintf("API error (429): rate limited, resets in %s", e.RateLimitReset)
+		}
+		return "API error (429): you are being rate limited; try again shortly"
+	}
 	if e.Message != "" {
 		return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
 	}
@@ -38,3 +74,48 @@ func (e *APIError) IsForbidden() bool {
 func (e *APIError) IsConflict() bool {
 	return e.StatusCode == 409
 }
+
+// IsRateLimited returns true if the error is a 429 Too Many Requests.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == 429
+}
+
+// parseRetryAfter reads the Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d.Round(time.Second)
+		}
+	}
+	return 0
+}
+
+// parseRateLimitReset reads X-RateLimit-Reset, which servers send either as
+// seconds until the reset or as a Unix timestamp, and returns the time left
+// until the reset rounded to the second.
+func parseRateLimitReset(header http.Header, now time.Time) time.Duration {
+	n, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil || n <= 0 {
+		return 0
+	}
+	// Anything this large is a timestamp rather than a delay
+	if n > 1_000_000_000 {
+		d := time.Unix(n, 0).Sub(now)
+		if d <= 0 {
+			return 0
+		}
+		return d.Round(time.Second)
+	}
+	return time.Duration(n) * time.Second
+}
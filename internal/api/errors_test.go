@@ -0,0 +1,228 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/morrisclay/scraps-cli/internal/log"
+)
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: 0},
+		{name: "seconds", value: "42", want: 42 * time.Second},
+		{name: "timestamp", value: strconv.FormatInt(now.Unix()+90, 10), want: 90 * time.Second},
+		{name: "past timestamp", value: strconv.FormatInt(now.Unix()-5, 10), want: 0},
+		{name: "garbage", value: "soon", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("X-RateLimit-Reset", tt.value)
+			}
+			if got := parseRateLimitReset(header, now); got != tt.want {
+				t.Errorf("parseRateLimitReset(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientRateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "42")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("slow down"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	_, err := client.GetStore("s")
+	if err == nil {
+		t.Fatal("GetStore() error = nil, want rate limit error")
+	}
+	if want := "rate limited, resets in 42s"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+	}
+}
+
+func TestTraceOmitsAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	Trace = &buf
+	defer func() { Trace = nil }()
+
+	client := NewClient(server.URL, "scraps_secretkey123")
+	if err := client.Get("/api/v1/user", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "GET "+server.URL+"/api/v1/user 200") {
+		t.Errorf("trace = %q, want method, URL and status", out)
+	}
+	if !strings.Contains(out, "rate limit remaining: 99") {
+		t.Errorf("trace = %q, want rate limit remaining", out)
+	}
+	if strings.Contains(out, "secretkey") {
+		t.Errorf("trace leaks the API key: %q", out)
+	}
+}
+
+func TestLogOmitsAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf, slog.LevelDebug)
+	defer log.SetOutput(nil, 0)
+
+	client := NewClient(server.URL, "scraps_secretkey123")
+	client.Get("/api/v1/user", nil)
+	client.Get("/api/v1/missing", nil)
+
+	out := buf.String()
+	for _, want := range []string{`msg="api request"`, "status=200", `msg="api error"`, "status=404"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secretkey") {
+		t.Errorf("log leaks the API key:\n%s", out)
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{status: 429, want: true},
+		{status: 403, want: false},
+		{status: 503, want: false},
+	}
+
+	for _, tt := range tests {
+		err := &APIError{StatusCode: tt.status}
+		if got := err.IsRateLimited(); got != tt.want {
+			t.Errorf("APIError{%d}.IsRateLimited() = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "missing", value: "", want: 0},
+		{name: "seconds", value: "7", want: 7 * time.Second},
+		{name: "http date", value: now.Add(2 * time.Minute).Format(http.TimeFormat), want: 2 * time.Minute},
+		{name: "past date", value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{name: "negative", value: "-3", want: 0},
+		{name: "garbage", value: "later", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			if got := parseRetryAfter(header, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBareRateLimitMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("<html>429</html>"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	_, err := client.GetStore("s")
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsRateLimited() {
+		t.Fatalf("GetStore() error = %v, want rate limit error", err)
+	}
+	if apiErr.RetryAfter != 3*time.Second {
+		t.Errorf("RetryAfter = %v, want 3s", apiErr.RetryAfter)
+	}
+	if want := "you are being rate limited; try again shortly"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+	}
+}
+
+func TestAPIErrorRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		want      string
+	}{
+		{name: "with header", requestID: "req-abc123", want: "API error (404): repository not found (request id: req-abc123)"},
+		{name: "without header", want: "API error (404): repository not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.requestID != "" {
+					w.Header().Set("X-Request-ID", tt.requestID)
+				}
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": "repository not found"}`))
+			}))
+			defer server.Close()
+
+			t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+			client := NewClient(server.URL, "test-key")
+			err := client.Get("/api/v1/stores/s/repos/missing", nil)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *APIError", err)
+			}
+			if apiErr.RequestID != tt.requestID {
+				t.Errorf("RequestID = %q, want %q", apiErr.RequestID, tt.requestID)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Error() = %q, want %q", err.Error(), tt.want)
+			}
+		})
+	}
+}
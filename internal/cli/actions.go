@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/tui/components"
+)
+
+// listAction is a follow-up offered after picking a store or repository in
+// an interactive list or in browse.
+type listAction struct {
+	name  string
+	label string
+	run   func(ref string) error
+}
+
+// repoActions returns the actions offered for a selected store/repo.
+func repoActions() []listAction {
+	return []listAction{
+		{"show", "Show details", runSubcommand(newRepoShowCmd)},
+		{"tree", "Browse files", runSubcommand(newFileTreeCmd)},
+		{"log", "View log", runSubcommand(newLogCmd)},
+		{"clone", "Clone", runSubcommand(newCloneCmd)},
+		{"copy-url", "Copy clone URL", copyRepoCloneURL},
+	}
+}
+
+// storeActions returns the actions offered for a selected store.
+func storeActions() []listAction {
+	return []listAction{
+		{"repos", "List repos", runSubcommand(newRepoListCmd)},
+		{"show", "Show details", runSubcommand(newStoreShowCmd)},
+		{"members", "Manage members", runSubcommand(newStoreMembersListCmd)},
+	}
+}
+
+// runSubcommand returns a function that runs a fresh instance of a command
+// with ref as its only argument.
+func runSubcommand(newCmd func() *cobra.Command) func(ref string) error {
+	return func(ref string) error {
+		cmd := newCmd()
+		cmd.SetArgs([]string{ref})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return cmd.Execute()
+	}
+}
+
+// copyRepoCloneURL copies the clone URL of a store/repo reference.
+func copyRepoCloneURL(ref string) error {
+	store, repo, err := parseStoreRepo(ref)
+	if err != nil {
+		return err
+	}
+	client, err := api.NewClientFromConfig("")
+	if err != nil {
+		return err
+	}
+	copyToClipboard("Clone URL", client.GetCloneURL(store, repo))
+	return nil
+}
+
+// findAction returns the action called name.
+func findAction(actions []listAction, name string) (listAction, bool) {
+	for _, a := range actions {
+		if a.name == name {
+			return a, true
+		}
+	}
+	return listAction{}, false
+}
+
+// runActionMenu asks what to do with ref and runs the chosen action.
+// Cancelling the menu does nothing.
+func runActionMenu(ref string, actions []listAction) error {
+	labels := make([]string, len(actions))
+	for i, a := range actions {
+		labels[i] = a.label
+	}
+
+	step := components.NewSelectStep("Action", fmt.Sprintf("What would you like to do with %s?", ref), labels)
+	values, err := components.RunWizard(ref, []components.WizardStep{step})
+	if err != nil || values == nil {
+		return err
+	}
+	return actions[step.SelectedIndex()].run(ref)
+}
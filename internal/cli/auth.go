@@ -2,8 +2,10 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -15,6 +17,7 @@ import (
 	"github.com/morrisclay/scraps-cli/internal/config"
 	"github.com/morrisclay/scraps-cli/internal/model"
 	"github.com/morrisclay/scraps-cli/internal/tui"
+	"github.com/morrisclay/scraps-cli/internal/tui/components"
 )
 
 // --- Login Command ---
@@ -27,8 +30,9 @@ func newLoginCmd() *cobra.Command {
 		Use:   "login",
 		Short: "Authenticate with your API key",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if host == "" {
-				host = config.GetHost()
+			host, err := resolveHost(host)
+			if err != nil {
+				return err
 			}
 
 			// Non-interactive mode
@@ -57,6 +61,15 @@ func newLoginCmd() *cobra.Command {
 	return cmd
 }
 
+// resolveHost returns the normalized --host value, or the configured default
+// host when the flag was not given.
+func resolveHost(host string) (string, error) {
+	if host == "" {
+		return api.DefaultHost(), nil
+	}
+	return config.NormalizeHost(host)
+}
+
 func loginWithKey(host, key string) error {
 	client := api.NewClient(host, key)
 	user, err := client.GetUser()
@@ -215,13 +228,23 @@ func runLoginTUI(host string) error {
 
 func newLogoutCmd() *cobra.Command {
 	var host string
+	var all, force bool
 
 	cmd := &cobra.Command{
-		Use:   "logout",
-		Short: "Clear saved credentials",
+		Use:     "logout",
+		Short:   "Clear saved credentials",
+		Example: "  scraps logout\n  scraps logout --all --force",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if host == "" {
-				host = config.GetHost()
+			if all {
+				if host != "" {
+					return fmt.Errorf("--host cannot be used with --all")
+				}
+				return logoutAll(force)
+			}
+
+			host, err := resolveHost(host)
+			if err != nil {
+				return err
 			}
 
 			if err := config.RemoveCredential(host); err != nil {
@@ -234,9 +257,46 @@ func newLogoutCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&host, "host", "H", "", "Server host")
+	cmd.Flags().BoolVar(&all, "all", false, "Clear credentials for every host")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
 	return cmd
 }
 
+// logoutAll clears every saved credential after confirming interactively.
+func logoutAll(force bool) error {
+	if !force && isInteractive() {
+		creds, err := config.LoadCredentials()
+		if err != nil {
+			return err
+		}
+		if len(creds) == 0 {
+			info("No saved credentials")
+			return nil
+		}
+
+		confirmed, err := components.RunConfirm(
+			"Log Out Everywhere",
+			fmt.Sprintf("Remove saved credentials for %d host(s)?", len(creds)),
+			true,
+		)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			info("Logout cancelled")
+			return nil
+		}
+	}
+
+	n, err := config.RemoveAllCredentials()
+	if err != nil {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
+
+	success(fmt.Sprintf("Logged out from %d host(s)", n))
+	return nil
+}
+
 // --- Signup Command ---
 
 func newSignupCmd() *cobra.Command {
@@ -246,8 +306,9 @@ func newSignupCmd() *cobra.Command {
 		Use:   "signup",
 		Short: "Create a new account",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if host == "" {
-				host = config.GetHost()
+			host, err := resolveHost(host)
+			if err != nil {
+				return err
 			}
 
 			// Non-interactive if both provided
@@ -294,7 +355,7 @@ func signupNonInteractive(host, username, email string) error {
 	}
 
 	success(fmt.Sprintf("Account created! Logged in as %s", resp.User.Username))
-	fmt.Printf("\nYour API key: %s\n", key)
+	fmt.Printf("\nYour API key: %s\n", maskSecret(key))
 	fmt.Println("Save this key - it won't be shown again!")
 	fmt.Println("\nCheck your email to verify your account.")
 	return nil
@@ -477,7 +538,7 @@ func (m signupModel) View() string {
 			tui.SuccessStyle.Render("✓"),
 			m.result.User.Username,
 			tui.LabelStyle.Render("Your API key:"),
-			key,
+			maskSecret(key),
 			tui.WarningStyle.Render("Save this key - it won't be shown again!"),
 			"Check your email to verify your account.",
 		)
@@ -504,9 +565,12 @@ func runSignupTUI(host, username, email string) error {
 // --- Whoami Command ---
 
 func newWhoamiCmd() *cobra.Command {
+	var full bool
+
 	cmd := &cobra.Command{
-		Use:   "whoami",
-		Short: "Show current user information",
+		Use:     "whoami",
+		Short:   "Show current user information",
+		Example: "  scraps whoami\n  scraps whoami --full -o json",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
@@ -518,8 +582,29 @@ func newWhoamiCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(user)
+			// Store lookup is best-effort; identity is still reported on failure
+			var stores []model.Store
+			var storesErr error
+			if full {
+				stores, storesErr = client.ListStores()
+			}
+
+			if isStructuredOutput() {
+				if !full {
+					return outputStructured(user)
+				}
+				result := map[string]any{
+					"user":        user,
+					"host":        client.Host(),
+					"auth_source": client.AuthSource(),
+					"stores":      stores,
+				}
+				if storesErr != nil {
+					result["stores_error"] = storesErr.Error()
+				}
+				if err := outputStructured(result); err != nil {
+					return err
+				}
 			} else {
 				if user.Username == "" && user.Email == "" && user.ID == "" {
 					fmt.Printf("Host: %s\n", client.Host())
@@ -530,21 +615,43 @@ func newWhoamiCmd() *cobra.Command {
 				fmt.Printf("Email:    %s\n", user.Email)
 				fmt.Printf("User ID:  %s\n", user.ID)
 				fmt.Printf("Host:     %s\n", client.Host())
+				fmt.Printf("Auth:     %s\n", describeAuthSource(client.AuthSource()))
+				if full {
+					if storesErr != nil {
+						fmt.Printf("Stores:   unavailable (%v)\n", storesErr)
+					} else {
+						fmt.Printf("Stores:   %d\n", len(stores))
+					}
+				}
 			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&full, "full", false, "Include accessible stores (makes an extra API call)")
 	return cmd
 }
 
 // --- Status Command ---
 
 func newStatusCmd() *cobra.Command {
+	var all, prune bool
+
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show login status and account info",
+		Example: `  scraps status
+  scraps status --all
+  scraps status --all --prune`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			host := config.GetHost()
+			if all {
+				return runStatusAll(prune)
+			}
+			if prune {
+				return fmt.Errorf("--prune requires --all")
+			}
+
+			host := api.DefaultHost()
 			cred, err := config.GetCredential(host)
 
 			fmt.Printf("Host: %s\n", host)
@@ -562,6 +669,7 @@ func newStatusCmd() *cobra.Command {
 			}
 
 			fmt.Println("Status: Logged in")
+			fmt.Printf("Auth: %s\n", describeAuthSource(cred.Source))
 			fmt.Printf("Username: %s\n", user.Username)
 			fmt.Printf("Email: %s\n", user.Email)
 			fmt.Printf("User ID: %s\n", user.ID)
@@ -575,5 +683,126 @@ func newStatusCmd() *cobra.Command {
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Check every host with saved credentials")
+	cmd.Flags().BoolVar(&prune, "prune", false, "With --all, remove credentials that are invalid or expired")
 	return cmd
 }
+
+// Credential check results reported by status --all.
+const (
+	credentialValid   = "valid"
+	credentialInvalid = "invalid"
+	credentialExpired = "expired"
+	credentialError   = "error"
+)
+
+// hostStatus is the result of checking one saved credential.
+type hostStatus struct {
+	Host     string `json:"host"`
+	Username string `json:"username,omitempty"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Pruned   bool   `json:"pruned,omitempty"`
+}
+
+// runStatusAll validates the saved credential for every host.
+func runStatusAll(prune bool) error {
+	creds, err := config.LoadCredentials()
+	if err != nil {
+		return err
+	}
+
+	hosts := make([]string, 0, len(creds))
+	for host := range creds {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	results := make([]hostStatus, 0, len(hosts))
+	for _, host := range hosts {
+		result := checkHostCredential(host, creds[host].Username)
+		if prune && (result.Status == credentialInvalid || result.Status == credentialExpired) {
+			if err := config.RemoveCredential(host); err != nil {
+				return fmt.Errorf("failed to remove credentials for %s: %w", host, err)
+			}
+			result.Pruned = true
+		}
+		results = append(results, result)
+	}
+
+	if isStructuredOutput() && !isDelimitedOutput() {
+		return outputStructured(results)
+	}
+
+	if len(results) == 0 {
+		info("No saved credentials")
+		return nil
+	}
+
+	headers := []string{"HOST", "USERNAME", "STATUS"}
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		status := r.Status
+		if r.Pruned {
+			status += " (removed)"
+		}
+		rows[i] = []string{r.Host, r.Username, status}
+	}
+	return outputTable(headers, rows)
+}
+
+// checkHostCredential calls GetUser with the saved key for host. Only a
+// 401 or 403 marks the credential invalid or expired; network failures,
+// other server errors and a missing key are reported as errors so they are
+// never pruned.
+func checkHostCredential(host, username string) hostStatus {
+	result := hostStatus{Host: host, Username: username}
+
+	cred, err := config.GetStoredCredential(host)
+	if err != nil {
+		result.Status = credentialError
+		result.Error = err.Error()
+		return result
+	}
+	if cred == nil || cred.APIKey == "" {
+		result.Status = credentialError
+		result.Error = "no API key stored"
+		return result
+	}
+
+	user, err := api.NewClient(host, cred.APIKey).GetUser()
+	if err != nil {
+		result.Error = err.Error()
+		var apiErr *api.APIError
+		switch {
+		case !errors.As(err, &apiErr) || (apiErr.StatusCode != 401 && apiErr.StatusCode != 403):
+			result.Status = credentialError
+		case strings.Contains(strings.ToLower(apiErr.Message), "expired"):
+			result.Status = credentialExpired
+		default:
+			result.Status = credentialInvalid
+		}
+		return result
+	}
+
+	result.Status = credentialValid
+	if user.Username != "" {
+		result.Username = user.Username
+	}
+	return result
+}
+
+// describeAuthSource returns a human-readable description of a credential source.
+func describeAuthSource(source string) string {
+	switch source {
+	case config.CredentialSourceEnv:
+		return "environment (SCRAPS_API_KEY)"
+	case config.CredentialSourceFile:
+		return "credentials file"
+	case config.CredentialSourceKeyring:
+		return "system keyring"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/config"
+)
+
+func TestCheckHostCredential(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer good":
+			json.NewEncoder(w).Encode(map[string]string{"id": "u1", "username": "alice"})
+		case "Bearer old":
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "API key expired"})
+		case "Bearer flaky":
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "upstream token expired"})
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid API key"})
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "good", want: credentialValid},
+		{key: "old", want: credentialExpired},
+		{key: "bad", want: credentialInvalid},
+		{key: "flaky", want: credentialError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if err := config.SetCredential(server.URL, config.Credential{APIKey: tt.key}); err != nil {
+				t.Fatalf("SetCredential() error = %v", err)
+			}
+			got := checkHostCredential(server.URL, "")
+			if got.Status != tt.want {
+				t.Errorf("Status = %v, want %v (error: %s)", got.Status, tt.want, got.Error)
+			}
+		})
+	}
+}
+
+func TestCheckHostCredentialUnreachable(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	host := "http://127.0.0.1:1"
+	if err := config.SetCredential(host, config.Credential{APIKey: "key"}); err != nil {
+		t.Fatalf("SetCredential() error = %v", err)
+	}
+
+	if got := checkHostCredential(host, ""); got.Status != credentialError {
+		t.Errorf("Status = %v, want %v", got.Status, credentialError)
+	}
+}
+
+func TestStatusAllPruneKeepsErrors(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid API key"})
+	}))
+	defer server.Close()
+
+	// A host whose key is missing is reported, not pruned
+	noKey := "https://nokey.example.com"
+	if err := config.SaveCredentials(config.Credentials{
+		server.URL: {APIKey: "bad"},
+		noKey:      {Username: "alice"},
+	}); err != nil {
+		t.Fatalf("SaveCredentials() error = %v", err)
+	}
+
+	var err error
+	out := captureStdout(t, func() { err = runStatusAll(true) })
+	if err != nil {
+		t.Fatalf("runStatusAll() error = %v", err)
+	}
+
+	var results []hostStatus
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	for _, r := range results {
+		wantPruned := r.Host == server.URL
+		if r.Pruned != wantPruned {
+			t.Errorf("%s: Pruned = %v (status %s), want %v", r.Host, r.Pruned, r.Status, wantPruned)
+		}
+	}
+
+	creds, err := config.LoadCredentials()
+	if err != nil {
+		t.Fatalf("LoadCredentials() error = %v", err)
+	}
+	if _, ok := creds[noKey]; !ok || len(creds) != 1 {
+		t.Errorf("credentials = %v, want only %s kept", creds, noKey)
+	}
+}
@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+	"github.com/morrisclay/scraps-cli/internal/tui"
+	"github.com/morrisclay/scraps-cli/internal/tui/components"
+)
+
+func newBrowseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Interactively explore stores and repositories",
+		Long: `Interactively explore stores and repositories.
+
+Pick a store, then a repository, then an action to run on it such as
+showing details, browsing files, viewing the log or cloning. Press esc to
+go back up a level and q to quit.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isInteractive() {
+				return fmt.Errorf("browse requires an interactive terminal; use 'scraps store list' or 'scraps repo list' instead")
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			p := tea.NewProgram(newBrowseModel(client), tea.WithAltScreen())
+			finalModel, err := p.Run()
+			if err != nil {
+				return err
+			}
+
+			m, ok := finalModel.(browseModel)
+			if !ok || m.action == "" {
+				return nil
+			}
+			action, ok := findAction(repoActions(), m.action)
+			if !ok {
+				return fmt.Errorf("unknown action %q", m.action)
+			}
+			return action.run(formatStoreRepo(m.store, m.repo))
+		},
+	}
+	return cmd
+}
+
+// browseModel drills down from stores to repos to actions. Each level is a
+// SearchList; levels holds one per level reached so far.
+type browseModel struct {
+	client  *api.Client
+	levels  []components.SearchListModel
+	parked  components.SearchListModel // active list as it was before enter
+	spinner spinner.Model
+	loading string // what is being loaded, empty when idle
+	err     error
+	width   int
+	height  int
+
+	store  string
+	repo   string
+	action string // chosen action, set when the browser exits
+}
+
+type browseStoresMsg struct {
+	stores []model.Store
+	err    error
+}
+
+type browseReposMsg struct {
+	repos []model.Repository
+	err   error
+}
+
+func newBrowseModel(client *api.Client) browseModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = tui.SpinnerStyle
+
+	return browseModel{
+		client:  client,
+		spinner: s,
+		loading: "stores",
+	}
+}
+
+func (m browseModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadStores())
+}
+
+func (m browseModel) loadStores() tea.Cmd {
+	return func() tea.Msg {
+		stores, err := m.client.ListStores()
+		return browseStoresMsg{stores: stores, err: err}
+	}
+}
+
+func (m browseModel) loadRepos(store string) tea.Cmd {
+	return func() tea.Msg {
+		repos, err := m.client.ListRepos(store)
+		return browseReposMsg{repos: repos, err: err}
+	}
+}
+
+// push makes list the active level, sized to the current window.
+func (m *browseModel) push(list components.SearchListModel) {
+	if m.width > 0 {
+		updated, _ := list.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		list = updated.(components.SearchListModel)
+	}
+	m.levels = append(m.levels, list)
+}
+
+func (m browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		for i := range m.levels {
+			updated, _ := m.levels[i].Update(msg)
+			m.levels[i] = updated.(components.SearchListModel)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		filtering := len(m.levels) > 0 && m.levels[len(m.levels)-1].Filtering()
+		if !filtering {
+			switch msg.String() {
+			case "q", "ctrl+c":
+				return m, tea.Quit
+			case "esc":
+				// Go back up a level, or quit from the top
+				if m.loading != "" || len(m.levels) <= 1 {
+					return m, tea.Quit
+				}
+				m.levels = m.levels[:len(m.levels)-1]
+				m.err = nil
+				return m, nil
+			case "enter":
+				if len(m.levels) > 0 {
+					m.parked = m.levels[len(m.levels)-1]
+				}
+			}
+		}
+		if m.loading != "" || len(m.levels) == 0 {
+			return m, nil
+		}
+
+	case components.SearchListSelectedMsg:
+		// Keep the list selectable for when the user comes back up
+		m.levels[len(m.levels)-1] = m.parked
+		m.err = nil
+		switch len(m.levels) {
+		case 1:
+			m.store = msg.Item.Value().(string)
+			m.loading = "repositories"
+			return m, tea.Batch(m.spinner.Tick, m.loadRepos(m.store))
+		case 2:
+			m.repo = msg.Item.Value().(string)
+			actions := repoActions()
+			items := make([]components.SearchListItem, len(actions))
+			for i, a := range actions {
+				items[i] = components.NewSearchListItem(a.label, a.name, a.name)
+			}
+			m.push(components.NewSearchList(formatStoreRepo(m.store, m.repo), items))
+			return m, nil
+		default:
+			m.action = msg.Item.Value().(string)
+			return m, tea.Quit
+		}
+
+	case browseStoresMsg:
+		m.loading = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		items := make([]components.SearchListItem, len(msg.stores))
+		for i, s := range msg.stores {
+			desc := fmt.Sprintf("Created: %s", formatDate(s.CreatedAt))
+			if s.Role != "" {
+				desc = s.Role + " · " + desc
+			}
+			items[i] = components.NewSearchListItem(s.Slug, desc, s.Slug)
+		}
+		m.push(components.NewSearchList("Stores", items))
+		return m, nil
+
+	case browseReposMsg:
+		m.loading = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		items := make([]components.SearchListItem, len(msg.repos))
+		for i, r := range msg.repos {
+			desc := fmt.Sprintf("Created: %s", formatDate(r.CreatedAt))
+			if r.Archived {
+				desc += " · archived"
+			}
+			items[i] = components.NewSearchListItem(r.Name, desc, r.Name)
+		}
+		m.push(components.NewSearchList(m.store, items))
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading != "" {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if len(m.levels) == 0 {
+		return m, nil
+	}
+	updated, cmd := m.levels[len(m.levels)-1].Update(msg)
+	m.levels[len(m.levels)-1] = updated.(components.SearchListModel)
+	return m, cmd
+}
+
+func (m browseModel) View() string {
+	var s strings.Builder
+
+	if m.loading != "" {
+		s.WriteString(m.spinner.View() + " Loading " + m.loading + "…\n")
+	} else if len(m.levels) > 0 {
+		s.WriteString(m.levels[len(m.levels)-1].View())
+		s.WriteString("\n")
+	}
+
+	if m.err != nil {
+		s.WriteString(tui.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		s.WriteString("\n")
+	}
+
+	s.WriteString(tui.HelpStyle.Render("enter select  / filter  esc back  q quit"))
+	return s.String()
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/morrisclay/scraps-cli/internal/model"
+	"github.com/morrisclay/scraps-cli/internal/tui/components"
+)
+
+// pressBrowse sends a key to the browser and delivers any resulting
+// selection message, without running load commands.
+func pressBrowse(t *testing.T, m browseModel, k tea.KeyMsg) (browseModel, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(k)
+	m = updated.(browseModel)
+	if cmd == nil {
+		return m, nil
+	}
+	if sel, ok := cmd().(components.SearchListSelectedMsg); ok {
+		updated, cmd = m.Update(sel)
+		return updated.(browseModel), cmd
+	}
+	return m, cmd
+}
+
+func TestBrowseModelNavigation(t *testing.T) {
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+
+	var m tea.Model = newBrowseModel(nil)
+	m, _ = m.Update(browseStoresMsg{stores: []model.Store{{Slug: "alpha"}, {Slug: "beta"}}})
+	bm := m.(browseModel)
+	if len(bm.levels) != 1 || bm.loading != "" {
+		t.Fatalf("after stores load: levels=%d loading=%q, want 1 and idle", len(bm.levels), bm.loading)
+	}
+
+	bm, _ = pressBrowse(t, bm, enter)
+	if bm.store != "alpha" || bm.loading != "repositories" {
+		t.Fatalf("after selecting store: store=%q loading=%q, want alpha and repositories", bm.store, bm.loading)
+	}
+
+	m, _ = bm.Update(browseReposMsg{repos: []model.Repository{{Name: "notes"}}})
+	bm = m.(browseModel)
+	if len(bm.levels) != 2 {
+		t.Fatalf("after repos load: levels=%d, want 2", len(bm.levels))
+	}
+
+	bm, _ = pressBrowse(t, bm, enter)
+	if bm.repo != "notes" || len(bm.levels) != 3 {
+		t.Fatalf("after selecting repo: repo=%q levels=%d, want notes and 3", bm.repo, len(bm.levels))
+	}
+
+	// esc goes back up without quitting
+	bm, cmd := pressBrowse(t, bm, esc)
+	if len(bm.levels) != 2 || cmd != nil {
+		t.Fatalf("after esc: levels=%d, want 2 and no quit", len(bm.levels))
+	}
+
+	// The repo list is still usable after coming back
+	bm, _ = pressBrowse(t, bm, enter)
+	if len(bm.levels) != 3 {
+		t.Fatalf("after reselecting repo: levels=%d, want 3", len(bm.levels))
+	}
+
+	bm, _ = pressBrowse(t, bm, tea.KeyMsg{Type: tea.KeyDown})
+	bm, cmd = pressBrowse(t, bm, enter)
+	if bm.action != "tree" {
+		t.Errorf("action = %q, want tree", bm.action)
+	}
+	if cmd == nil {
+		t.Error("choosing an action did not quit the browser")
+	}
+}
+
+func TestActionMenus(t *testing.T) {
+	for name, actions := range map[string][]listAction{"repo": repoActions(), "store": storeActions()} {
+		seen := map[string]bool{}
+		for _, a := range actions {
+			if seen[a.name] {
+				t.Errorf("%s actions: duplicate name %q", name, a.name)
+			}
+			seen[a.name] = true
+			if a.run == nil {
+				t.Errorf("%s action %q has no run function", name, a.name)
+			}
+			if got, ok := findAction(actions, a.name); !ok || got.label != a.label {
+				t.Errorf("findAction(%q) = %v, %v", a.name, got.label, ok)
+			}
+		}
+	}
+
+	if _, ok := findAction(repoActions(), "nope"); ok {
+		t.Error("findAction(nope) found an action")
+	}
+}
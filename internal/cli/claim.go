@@ -1,9 +1,17 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 
 	"github.com/morrisclay/scraps-cli/internal/api"
@@ -14,12 +22,29 @@ import (
 func newClaimCmd() *cobra.Command {
 	var message, agentID string
 	var ttl int
+	var wait, hold, check bool
+	var waitTimeout time.Duration
 
 	cmd := &cobra.Command{
-		Use:   "claim <store/repo:branch> <patterns...>",
-		Short: "Claim file patterns for exclusive access",
+		Use:     "claim <store/repo:branch> <patterns...>",
+		Aliases: []string{"claims"},
+		Short:   "Claim file patterns for exclusive access",
+		Long: `Claim file patterns for exclusive access.
+
+If other agents hold overlapping claims the command fails (exit code 4) and
+lists them; with --output json they are reported as a "conflicts" array.
+
+--check only reports whether the patterns are free: it compares them with
+the active claims on the branch and never claims anything itself. Claims
+held by this agent are not counted as conflicts. The server has no dry-run
+claim, so the overlap test runs locally and only approximates the server's
+rules (* within a directory, ** across them, ?, [...] classes); a real claim
+can still be rejected after a clean check.`,
 		Example: `  scraps claim mystore/myrepo:main "*.go"
-  scraps claim mystore/myrepo:main "src/*.ts" "lib/*.ts" --message "Working on frontend"`,
+  scraps claim mystore/myrepo:main "src/*.ts" "lib/*.ts" --message "Working on frontend"
+  scraps claim mystore/myrepo:main "*.go" --wait --wait-timeout 60s
+  scraps claim mystore/myrepo:main "*.go" --hold
+  scraps claim mystore/myrepo:main "*.go" --check -o json`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 2 {
 				return fmt.Errorf("missing arguments\n\nUsage: scraps claim <store/repo:branch> <patterns...>\n\nExample: scraps claim mystore/myrepo:main \"*.go\"")
@@ -35,12 +60,19 @@ func newClaimCmd() *cobra.Command {
 			if branch == "" {
 				return fmt.Errorf("branch is required (use store/repo:branch format)")
 			}
+			if check && (wait || hold) {
+				return fmt.Errorf("--check cannot be combined with --wait or --hold")
+			}
 
 			patterns := args[1:]
 
-			// Generate agent ID if not provided
-			if agentID == "" {
-				agentID = "cli-" + uuid.New().String()[:8]
+			// Fall back to the persisted agent identity
+			explicitAgentID := agentID != ""
+			if !explicitAgentID {
+				agentID, err = config.GetAgentID()
+				if err != nil {
+					return err
+				}
 			}
 
 			if message == "" {
@@ -59,36 +91,86 @@ func newClaimCmd() *cobra.Command {
 				TTLSeconds: ttl,
 			}
 
+			if check {
+				claims, err := client.ListClaims(store, repo, branch)
+				if err != nil {
+					return err
+				}
+				return reportClaimCheck(req, claimConflicts(claims, agentID, patterns))
+			}
+
 			resp, err := client.Claim(store, repo, branch, req)
 			if err != nil {
 				return err
 			}
 
+			// Retry with backoff until the conflict clears or we time out
+			if wait && isClaimConflict(resp) {
+				deadline := time.Now().Add(waitTimeout)
+				delay := claimWaitBaseDelay
+				pause := delay
+				for isClaimConflict(resp) && time.Now().Add(pause).Before(deadline) {
+					if !isStructuredOutput() {
+						info(fmt.Sprintf("Patterns held by %s, retrying in %s...", conflictHolders(resp.Conflicts), pause))
+					}
+					time.Sleep(pause)
+
+					next, err := client.Claim(store, repo, branch, req)
+					if retryAfter, limited := rateLimitRetryAfter(err); limited {
+						// Wait as long as the server asks instead of backing off
+						if !isStructuredOutput() {
+							warn("Rate limited by the server")
+						}
+						pause = max(retryAfter, delay)
+						continue
+					}
+					if err != nil {
+						return err
+					}
+					resp = next
+
+					delay *= 2
+					if delay > claimWaitMaxDelay {
+						delay = claimWaitMaxDelay
+					}
+					pause = delay
+				}
+
+				if isClaimConflict(resp) {
+					err := describeError(errClaimConflict, "timed out after %s waiting for claim: patterns still held by %s", waitTimeout, conflictHolders(resp.Conflicts))
+					return reportClaimConflict(req, resp.Conflicts, err)
+				}
+			}
+
 			// Check for conflicts
-			if resp.Type == "claim_conflict" && len(resp.Conflicts) > 0 {
-				errorf("Claim conflict detected!")
-				fmt.Println("\nConflicting claims:")
-				for _, c := range resp.Conflicts {
-					fmt.Printf("  Agent: %s (%s)\n", c.AgentName, c.AgentID)
-					fmt.Printf("  Patterns: %v\n", c.Patterns)
-					fmt.Printf("  Claim: %s\n\n", c.Claim)
+			if isClaimConflict(resp) {
+				if !isStructuredOutput() {
+					errorf("Claim conflict detected!")
 				}
-				return fmt.Errorf("cannot claim: patterns conflict with existing claims")
+				return reportClaimConflict(req, resp.Conflicts, fmt.Errorf("cannot claim: %w", errClaimConflict))
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(map[string]any{
+			if isStructuredOutput() {
+				if err := outputStructured(map[string]any{
 					"agent_id":   agentID,
 					"patterns":   patterns,
 					"expires_at": resp.GetExpiresAtString(),
-				})
+				}); err != nil {
+					return err
+				}
 			} else {
 				success(fmt.Sprintf("Claimed patterns as %s", agentID))
 				fmt.Printf("Patterns: %v\n", patterns)
 				if expiresAt := resp.GetExpiresAtString(); expiresAt != nil {
 					fmt.Printf("Expires: %s\n", *expiresAt)
 				}
-				info(fmt.Sprintf("Use --agent-id %s to release", agentID))
+				if !hold && explicitAgentID {
+					info(fmt.Sprintf("Use --agent-id %s to release", agentID))
+				}
+			}
+
+			if hold {
+				return holdClaim(client, store, repo, branch, req)
 			}
 
 			return nil
@@ -96,22 +178,392 @@ func newClaimCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&message, "message", "m", "", "Claim description")
-	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (auto-generated if not provided)")
+	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (defaults to the stored agent_id)")
 	cmd.Flags().IntVar(&ttl, "ttl", 300, "Claim TTL in seconds")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for conflicting claims to be released")
+	cmd.Flags().BoolVar(&hold, "hold", false, "Keep renewing the claim until interrupted, then release it")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 5*time.Minute, "Maximum time to wait with --wait")
+	cmd.Flags().BoolVar(&check, "check", false, "Report whether the patterns could be claimed without keeping the claim")
+
+	cmd.AddCommand(newClaimListCmd())
+	cmd.AddCommand(newClaimWatchCmd())
 
 	return cmd
 }
 
+// Backoff bounds for claim --wait retries.
+const (
+	claimWaitBaseDelay = time.Second
+	claimWaitMaxDelay  = 15 * time.Second
+)
+
+// holdClaim re-issues a claim at half its TTL until the process is
+// interrupted, then releases it.
+func holdClaim(client *api.Client, store, repo, branch string, req model.ClaimRequest) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	interval := time.Duration(req.TTLSeconds) * time.Second / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	defer func() {
+		release := model.ReleaseRequest{AgentID: req.AgentID, Patterns: req.Patterns}
+		if err := client.Release(store, repo, branch, release); err != nil {
+			errorf("Failed to release claim: %v", err)
+			return
+		}
+		if !isStructuredOutput() {
+			success(fmt.Sprintf("Released patterns as %s", req.AgentID))
+		}
+	}()
+
+	if !isStructuredOutput() {
+		info(fmt.Sprintf("Holding claim, renewing every %s (Ctrl+C to release)", interval))
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if !isStructuredOutput() {
+				fmt.Println()
+			}
+			return nil
+		case <-ticker.C:
+			resp, err := client.Claim(store, repo, branch, req)
+			if err != nil {
+				return fmt.Errorf("failed to renew claim: %w", err)
+			}
+			if isClaimConflict(resp) {
+				err := describeError(errClaimConflict, "failed to renew claim: patterns now held by %s", conflictHolders(resp.Conflicts))
+				return reportClaimConflict(req, resp.Conflicts, err)
+			}
+			if !isStructuredOutput() {
+				expires := ""
+				if expiresAt := resp.GetExpiresAtString(); expiresAt != nil {
+					expires = fmt.Sprintf(" (expires %s)", formatDateTime(*expiresAt))
+				}
+				info(fmt.Sprintf("%s Renewed claim%s", time.Now().Format("15:04:05"), expires))
+			}
+		}
+	}
+}
+
+// errClaimConflict is the cause of claim failures due to other agents'
+// claims, so they exit with the conflict code.
+var errClaimConflict = errors.New("patterns conflict with existing claims")
+
+// reportClaimConflict describes the claims that blocked req and returns err.
+// In structured output mode they are printed as the command's result, with
+// err marked as already reported.
+func reportClaimConflict(req model.ClaimRequest, conflicts []model.ClaimConflict, err error) error {
+	if !isStructuredOutput() {
+		printClaimConflicts(conflicts)
+		return err
+	}
+	if outErr := outputStructured(map[string]any{
+		"agent_id":  req.AgentID,
+		"patterns":  req.Patterns,
+		"claimed":   false,
+		"conflicts": conflicts,
+	}); outErr != nil {
+		return outErr
+	}
+	return &reportedError{cause: err}
+}
+
+// reportClaimCheck prints the result of claim --check.
+func reportClaimCheck(req model.ClaimRequest, conflicts []model.ClaimConflict) error {
+	available := len(conflicts) == 0
+
+	if isStructuredOutput() {
+		return outputStructured(map[string]any{
+			"agent_id":  req.AgentID,
+			"patterns":  req.Patterns,
+			"available": available,
+			"conflicts": conflicts,
+		})
+	}
+
+	if available {
+		fmt.Printf("Available: %s\n", strings.Join(req.Patterns, ", "))
+		return nil
+	}
+	fmt.Printf("Held by: %s\n", conflictHolders(conflicts))
+	printClaimConflicts(conflicts)
+	return nil
+}
+
+// claimConflicts returns the claims held by other agents whose patterns
+// overlap patterns, with each conflict listing only the overlapping ones.
+func claimConflicts(claims []model.Claim, agentID string, patterns []string) []model.ClaimConflict {
+	conflicts := []model.ClaimConflict{}
+	for _, c := range claims {
+		if c.AgentID == agentID {
+			continue
+		}
+		var held []string
+		for _, h := range c.Patterns {
+			if slices.ContainsFunc(patterns, func(p string) bool { return globsOverlap(p, h) }) {
+				held = append(held, h)
+			}
+		}
+		if len(held) > 0 {
+			conflicts = append(conflicts, model.ClaimConflict{
+				AgentName: c.AgentName,
+				AgentID:   c.AgentID,
+				Patterns:  held,
+				Claim:     c.Claim,
+			})
+		}
+	}
+	return conflicts
+}
+
+// globToken is one element of a claim pattern: a single character matcher
+// in path.Match syntax, or a * (within a directory) or ** (across them).
+type globToken struct {
+	text string
+	star bool
+	deep bool
+}
+
+// tokenizeGlob splits a claim pattern into tokens. An unterminated [ is
+// treated as a literal.
+func tokenizeGlob(pattern string) []globToken {
+	var tokens []globToken
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				tokens = append(tokens, globToken{text: "**", star: true, deep: true})
+				i++
+			} else {
+				tokens = append(tokens, globToken{text: "*", star: true})
+			}
+		case r == '\\' && i+1 < len(runes):
+			tokens = append(tokens, globToken{text: string(runes[i : i+2])})
+			i++
+		case r == '[':
+			end := i + 1
+			if end < len(runes) && runes[end] == '^' {
+				end++
+			}
+			if end < len(runes) && runes[end] == ']' {
+				end++
+			}
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end < len(runes) {
+				tokens = append(tokens, globToken{text: string(runes[i : end+1])})
+				i = end
+			} else {
+				tokens = append(tokens, globToken{text: `\[`})
+			}
+		default:
+			tokens = append(tokens, globToken{text: string(r)})
+		}
+	}
+	return tokens
+}
+
+// matchesRune reports whether a single-character token (or one step of a
+// star) accepts r.
+func (t globToken) matchesRune(r rune) bool {
+	switch {
+	case t.deep:
+		return true
+	case t.star:
+		return r != '/'
+	}
+	ok, _ := path.Match(t.text, string(r))
+	return ok
+}
+
+// tokensIntersect reports whether some character is accepted by both a and
+// b. Candidates are the characters named in either token plus a few
+// ordinary ones, which is enough for the wildcards and classes claims use.
+func tokensIntersect(a, b globToken) bool {
+	for _, r := range a.text + b.text + "a0_." {
+		if a.matchesRune(r) && b.matchesRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipsDir reports whether tokens[i] is a ** followed by a slash, which
+// together can match an empty directory prefix.
+func skipsDir(tokens []globToken, i int) bool {
+	return tokens[i].deep && i+1 < len(tokens) && tokens[i+1].text == "/"
+}
+
+// globsOverlap reports whether some path could match both claim patterns.
+func globsOverlap(a, b string) bool {
+	ta, tb := tokenizeGlob(a), tokenizeGlob(b)
+	seen := make(map[[2]int]bool)
+	var overlap func(i, j int) bool
+	overlap = func(i, j int) bool {
+		if i == len(ta) && j == len(tb) {
+			return true
+		}
+		key := [2]int{i, j}
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+
+		// A star can match nothing, and **/ can match no directories
+		if i < len(ta) && ta[i].star && (overlap(i+1, j) || skipsDir(ta, i) && overlap(i+2, j)) {
+			return true
+		}
+		if j < len(tb) && tb[j].star && (overlap(i, j+1) || skipsDir(tb, j) && overlap(i, j+2)) {
+			return true
+		}
+		if i == len(ta) || j == len(tb) || (ta[i].star && tb[j].star) {
+			return false
+		}
+
+		// Consume one character on both sides; a star stays in place
+		if !tokensIntersect(ta[i], tb[j]) {
+			return false
+		}
+		ni, nj := i+1, j+1
+		if ta[i].star {
+			ni = i
+		}
+		if tb[j].star {
+			nj = j
+		}
+		return overlap(ni, nj)
+	}
+	return overlap(0, 0)
+}
+
+// isClaimConflict reports whether a claim was rejected due to conflicts.
+func isClaimConflict(resp *model.ClaimResponse) bool {
+	return resp.Type == "claim_conflict" && len(resp.Conflicts) > 0
+}
+
+// printClaimConflicts prints the claims that blocked a claim request.
+func printClaimConflicts(conflicts []model.ClaimConflict) {
+	fmt.Println("\nConflicting claims:")
+	for _, c := range conflicts {
+		fmt.Printf("  Agent: %s (%s)\n", c.AgentName, c.AgentID)
+		fmt.Printf("  Patterns: %v\n", c.Patterns)
+		fmt.Printf("  Claim: %s\n\n", c.Claim)
+	}
+}
+
+// conflictHolders returns a comma-separated list of the agents holding
+// conflicting claims.
+func conflictHolders(conflicts []model.ClaimConflict) string {
+	var holders []string
+	for _, c := range conflicts {
+		if !slices.Contains(holders, c.AgentID) {
+			holders = append(holders, c.AgentID)
+		}
+	}
+	return strings.Join(holders, ", ")
+}
+
+func newClaimListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list <store/repo:branch>",
+		Short:   "List active claims on a branch",
+		Example: "  scraps claim list mystore/myrepo:main",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("branch reference required\n\nUsage: scraps claim list <store/repo:branch>\n\nExample: scraps claim list mystore/myrepo:main")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, repo, branch, err := parseStoreRepoBranch(args[0])
+			if err != nil {
+				return err
+			}
+
+			if branch == "" {
+				return fmt.Errorf("branch is required (use store/repo:branch format)")
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			claims, err := client.ListClaims(store, repo, branch)
+			if err != nil {
+				return err
+			}
+
+			if isStructuredOutput() && !isDelimitedOutput() {
+				return outputStructured(claims)
+			}
+
+			if len(claims) == 0 {
+				info("No active claims")
+				return nil
+			}
+
+			headers := []string{"AGENT", "PATTERNS", "CLAIM", "EXPIRES"}
+			rows := make([][]string, len(claims))
+			for i, c := range claims {
+				expires := "-"
+				if expiresAt := c.GetExpiresAtString(); expiresAt != nil {
+					expires = formatTableDateTime(*expiresAt)
+				}
+				rows[i] = []string{
+					c.AgentID,
+					strings.Join(c.Patterns, ", "),
+					truncate(c.Claim, 40),
+					expires,
+				}
+			}
+
+			_, err = outputWithInteractiveTable("Claims", claims, headers, rows)
+			return err
+		},
+	}
+	return cmd
+}
+
 func newReleaseCmd() *cobra.Command {
 	var agentID string
+	var all bool
 
 	cmd := &cobra.Command{
-		Use:   "release <store/repo:branch> <patterns...>",
+		Use:   "release <store/repo:branch> [patterns...]",
 		Short: "Release claimed file patterns",
-		Example: `  scraps release mystore/myrepo:main "*.go" --agent-id cli-abc123`,
+		Long: `Release claimed file patterns.
+
+Without --agent-id, the agent identity stored in config (agent_id) is used,
+which is the same identity scraps claim uses by default.
+
+--all releases every pattern the agent holds on the branch instead of the
+patterns given, which is useful after a crash left claims behind.`,
+		Example: `  scraps release mystore/myrepo:main "*.go"
+  scraps release mystore/myrepo:main "*.go" --agent-id cli-abc123
+  scraps release mystore/myrepo:main --all`,
 		Args: func(cmd *cobra.Command, args []string) error {
+			if all {
+				if len(args) < 1 {
+					return fmt.Errorf("branch reference required\n\nUsage: scraps release <store/repo:branch> --all\n\nExample: scraps release mystore/myrepo:main --all")
+				}
+				if len(args) > 1 {
+					return fmt.Errorf("patterns cannot be given with --all")
+				}
+				return nil
+			}
 			if len(args) < 2 {
-				return fmt.Errorf("missing arguments\n\nUsage: scraps release <store/repo:branch> <patterns...> --agent-id <id>\n\nExample: scraps release mystore/myrepo:main \"*.go\" --agent-id cli-abc123")
+				return fmt.Errorf("missing arguments\n\nUsage: scraps release <store/repo:branch> <patterns...>\n\nExample: scraps release mystore/myrepo:main \"*.go\"")
 			}
 			return nil
 		},
@@ -126,7 +578,10 @@ func newReleaseCmd() *cobra.Command {
 			}
 
 			if agentID == "" {
-				return fmt.Errorf("--agent-id is required")
+				agentID, err = config.GetAgentID()
+				if err != nil {
+					return err
+				}
 			}
 
 			patterns := args[1:]
@@ -136,6 +591,25 @@ func newReleaseCmd() *cobra.Command {
 				return err
 			}
 
+			if all {
+				released, err := client.ReleaseAll(store, repo, branch, agentID)
+				if err != nil {
+					return err
+				}
+				if isStructuredOutput() {
+					return outputStructured(map[string]any{
+						"agent_id": agentID,
+						"released": released,
+					})
+				}
+				if len(released) == 0 {
+					info(fmt.Sprintf("No claims held by %s", agentID))
+					return nil
+				}
+				success(fmt.Sprintf("Released %d pattern(s) as %s", len(released), agentID))
+				return nil
+			}
+
 			req := model.ReleaseRequest{
 				AgentID:  agentID,
 				Patterns: patterns,
@@ -150,8 +624,8 @@ func newReleaseCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID from the claim")
-	cmd.MarkFlagRequired("agent-id")
+	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID from the claim (defaults to the stored agent_id)")
+	cmd.Flags().BoolVar(&all, "all", false, "Release every pattern the agent holds on the branch")
 
 	return cmd
 }
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+// claimServer answers claims with conflicts when conflict is set, and
+// records whether a release was made.
+func claimServer(t *testing.T, conflict bool, released *bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/coordinate/claim") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodPost:
+			if conflict {
+				json.NewEncoder(w).Encode(model.ClaimResponse{
+					Type:      "claim_conflict",
+					Conflicts: []model.ClaimConflict{{AgentID: "agent-b", Patterns: []string{"*.go"}, Claim: "refactor"}},
+				})
+				return
+			}
+			json.NewEncoder(w).Encode(model.ClaimResponse{Type: "claim_ok", ExpiresAt: "2026-01-01T00:00:00Z"})
+		case http.MethodDelete:
+			*released = true
+			w.Write([]byte(`{}`))
+		}
+	}))
+}
+
+func TestClaimConflictJSON(t *testing.T) {
+	var released bool
+	server := claimServer(t, true, &released)
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+	cmd := newClaimCmd()
+	cmd.SetArgs([]string{"s/r:main", "*.go", "--agent-id", "agent-a"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	var err error
+	out := captureStdout(t, func() {
+		err = cmd.Execute()
+		if err != nil {
+			reportError(err)
+		}
+	})
+
+	if got := exitCode(err); got != exitConflict {
+		t.Errorf("exit code = %d (%v), want %d", got, err, exitConflict)
+	}
+	var result struct {
+		Claimed   bool                  `json:"claimed"`
+		Conflicts []model.ClaimConflict `json:"conflicts"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not a single JSON result: %v\n%s", err, out)
+	}
+	if result.Claimed || len(result.Conflicts) != 1 || result.Conflicts[0].AgentID != "agent-b" {
+		t.Errorf("result = %+v, want the agent-b conflict", result)
+	}
+}
+
+func TestHoldClaimRenewalConflictJSON(t *testing.T) {
+	var released bool
+	server := claimServer(t, true, &released)
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+	client := api.NewClient(server.URL, "test-key")
+
+	req := model.ClaimRequest{AgentID: "agent-a", Patterns: []string{"*.go"}, TTLSeconds: 1}
+	var err error
+	out := captureStdout(t, func() {
+		err = holdClaim(client, "s", "r", "main", req)
+		if err != nil {
+			reportError(err)
+		}
+	})
+
+	if got := exitCode(err); got != exitConflict {
+		t.Errorf("exit code = %d (%v), want %d", got, err, exitConflict)
+	}
+	var result struct {
+		Claimed   bool                  `json:"claimed"`
+		Conflicts []model.ClaimConflict `json:"conflicts"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("stdout is not a single JSON result: %v\n%s", err, out)
+	}
+	if result.Claimed || len(result.Conflicts) != 1 || result.Conflicts[0].AgentID != "agent-b" {
+		t.Errorf("result = %+v, want the agent-b conflict", result)
+	}
+	if !released {
+		t.Error("claim was not released after the failed renewal")
+	}
+}
+
+func TestClaimCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		held          []model.Claim
+		wantAvailable bool
+	}{
+		{name: "free", held: []model.Claim{{AgentID: "agent-b", Patterns: []string{"*.ts"}}}, wantAvailable: true},
+		{name: "held", held: []model.Claim{{AgentID: "agent-b", Patterns: []string{"*.ts", "main.go"}, Claim: "refactor"}}},
+		{name: "held by self", held: []model.Claim{{AgentID: "agent-a", Patterns: []string{"*.go"}}}, wantAvailable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// --check must never claim or release anything
+				if r.Method != http.MethodGet {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewEncoder(w).Encode(tt.held)
+			}))
+			defer server.Close()
+
+			t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+			t.Setenv("SCRAPS_HOST", server.URL)
+			t.Setenv("SCRAPS_API_KEY", "test-key")
+			t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+			cmd := newClaimCmd()
+			cmd.SetArgs([]string{"s/r:main", "*.go", "--agent-id", "agent-a", "--check"})
+			var err error
+			out := captureStdout(t, func() { err = cmd.Execute() })
+			if err != nil {
+				t.Fatalf("claim --check error = %v", err)
+			}
+
+			var result struct {
+				Available bool                  `json:"available"`
+				Conflicts []model.ClaimConflict `json:"conflicts"`
+			}
+			if err := json.Unmarshal([]byte(out), &result); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, out)
+			}
+			if result.Available != tt.wantAvailable || (len(result.Conflicts) > 0) == tt.wantAvailable {
+				t.Errorf("result = %+v, want available=%v", result, tt.wantAvailable)
+			}
+			if !tt.wantAvailable && !reflect.DeepEqual(result.Conflicts[0].Patterns, []string{"main.go"}) {
+				t.Errorf("conflict patterns = %v, want only the overlapping main.go", result.Conflicts[0].Patterns)
+			}
+		})
+	}
+}
+
+func TestGlobsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "*.ts", false},
+		{"src/*.ts", "src/a*", true},
+		{"src/*.ts", "lib/*.ts", false},
+		{"*.go", "cmd/main.go", false},
+		{"**/*.go", "cmd/main.go", true},
+		{"src/**", "src/a/b.ts", true},
+		{"file?.txt", "file1.txt", true},
+		{"file[0-9].txt", "file[a-z].txt", false},
+		{"file[0-9].txt", "file?.txt", true},
+		{"docs/*", "docs", false},
+		{"docs/**", "docs/a/b/c.md", true},
+		{"**", "anything/at/all", true},
+		{"**/test/*.go", "pkg/*/test/x.go", true},
+		{"**/*.go", "main.go", true},
+		{"src/**/*.go", "src/main.go", true},
+		{"src/*/main.go", "src/a/b/main.go", false},
+		{"[^a-z]*", "abc", false},
+		{"[^a-z]*", "Makefile", true},
+		{`\*.md`, "*.md", true},
+		{`\*.md`, "README.md", false},
+		{"a?c", "a/c", false},
+	}
+
+	for _, tt := range tests {
+		if got := globsOverlap(tt.a, tt.b); got != tt.want {
+			t.Errorf("globsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if got := globsOverlap(tt.b, tt.a); got != tt.want {
+			t.Errorf("globsOverlap(%q, %q) = %v, want %v", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestClaimConflicts(t *testing.T) {
+	claims := []model.Claim{
+		{AgentID: "me", Patterns: []string{"*.go"}},
+		{AgentID: "agent-a", AgentName: "alice", Patterns: []string{"src/**", "docs/*.md"}},
+		{AgentID: "agent-b", Patterns: []string{"*.ts", "web/*"}},
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     map[string][]string
+	}{
+		{"own claims ignored", []string{"main.go"}, map[string][]string{}},
+		{"nested path", []string{"src/api/client.go"}, map[string][]string{"agent-a": {"src/**"}}},
+		{"only overlapping patterns listed", []string{"docs/*"}, map[string][]string{"agent-a": {"docs/*.md"}}},
+		{"several agents", []string{"**/*.ts"}, map[string][]string{"agent-a": {"src/**"}, "agent-b": {"*.ts", "web/*"}}},
+		{"free", []string{"lib/*.rs"}, map[string][]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string][]string{}
+			for _, c := range claimConflicts(claims, "me", tt.patterns) {
+				got[c.AgentID] = c.Patterns
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("claimConflicts(%v) = %v, want %v", tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseAll(t *testing.T) {
+	var released model.ReleaseRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode([]model.Claim{
+				{AgentID: "agent-a", Patterns: []string{"*.go", "docs/*"}},
+				{AgentID: "agent-b", Patterns: []string{"*.ts"}},
+			})
+		case http.MethodDelete:
+			json.NewDecoder(r.Body).Decode(&released)
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+	cmd := newReleaseCmd()
+	cmd.SetArgs([]string{"s/r:main", "--all", "--agent-id", "agent-a"})
+	var err error
+	out := captureStdout(t, func() { err = cmd.Execute() })
+	if err != nil {
+		t.Fatalf("release --all error = %v", err)
+	}
+
+	var result struct {
+		Released []string `json:"released"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+	if len(result.Released) != 2 || released.AgentID != "agent-a" || len(released.Patterns) != 2 {
+		t.Errorf("released %v (request %+v), want agent-a's two patterns", result.Released, released)
+	}
+}
+
+func TestReleaseArgs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"s/r:main"}, wantErr: "missing arguments"},
+		{args: []string{"s/r:main", "*.go", "--all"}, wantErr: "cannot be given with --all"},
+		{args: []string{"--all"}, wantErr: "branch reference required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cmd := newReleaseCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+	"github.com/morrisclay/scraps-cli/internal/stream"
+	"github.com/morrisclay/scraps-cli/internal/tui"
+	"github.com/morrisclay/scraps-cli/internal/tui/components"
+)
+
+func newClaimWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch <store/repo:branch>",
+		Short: "Show a live dashboard of claims on a branch",
+		Long: `Show a live dashboard of claims on a branch.
+
+The active claims are listed by agent and pattern with a countdown to their
+expiry, and the table updates as claims and releases stream in. For a plain
+event log instead, use 'scraps watch <store/repo> --claims-only'.`,
+		Example: "  scraps claim watch mystore/myrepo:main",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("branch reference required\n\nUsage: scraps claim watch <store/repo:branch>\n\nExample: scraps claim watch mystore/myrepo:main")
+			}
+			return nil
+		},
+		ValidArgsFunction: completeBranchRef(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, repo, branch, err := parseStoreRepoBranch(args[0])
+			if err != nil {
+				return err
+			}
+			if branch == "" {
+				return fmt.Errorf("branch is required (use store/repo:branch format)")
+			}
+			if !isInteractive() || isStructuredOutput() {
+				return fmt.Errorf("claim watch needs a terminal; use 'scraps watch %s/%s:%s --claims-only' to stream claim events", store, repo, branch)
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			return runClaimWatch(client, store, repo, branch)
+		},
+	}
+	return cmd
+}
+
+// runClaimWatch runs the claims dashboard until the user quits. Stream
+// callbacks hand events to the program with Send, which stops blocking
+// once the program has exited.
+func runClaimWatch(client *api.Client, store, repo, branch string) error {
+	m := newClaimWatchModel(client, store, repo, branch)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	streamURL := client.BuildStreamURL(store, repo, &api.StreamOptions{Branch: branch})
+	streamClient := stream.NewClient(streamURL, client.APIKey()).WithReconnect(watchMaxReconnects)
+	streamClient.OnMessage = func(data []byte) {
+		event, err := model.DecodeEvent(data)
+		if err != nil {
+			return
+		}
+		if msg, ok := newClaimEventMsg(event); ok {
+			p.Send(msg)
+		}
+	}
+	streamClient.OnReconnect = func(attempt int, delay time.Duration) {
+		p.Send(claimStreamStatusMsg(fmt.Sprintf("reconnecting in %s (attempt %d/%d)", delay, attempt, watchMaxReconnects)))
+	}
+	streamClient.OnError = func(err error) {
+		p.Send(claimStreamStatusMsg(fmt.Sprintf("disconnected: %v", err)))
+	}
+	if err := streamClient.Connect(); err != nil {
+		return fmt.Errorf("failed to connect to event stream: %w", err)
+	}
+	defer streamClient.Close()
+
+	_, err := p.Run()
+	return err
+}
+
+// heldClaim is one pattern held by an agent. A zero expires means the
+// expiry is not known yet.
+type heldClaim struct {
+	claim   string
+	expires time.Time
+}
+
+// claimsLoadedMsg carries the result of listing the branch's claims.
+type claimsLoadedMsg struct {
+	claims []model.Claim
+	err    error
+}
+
+// claimEventMsg is a claim or release seen on the event stream.
+type claimEventMsg struct {
+	agentID  string
+	patterns []string
+	claim    string
+	release  bool
+}
+
+// claimStreamStatusMsg describes a change in the stream connection.
+type claimStreamStatusMsg string
+
+// claimTickMsg redraws the expiry countdowns.
+type claimTickMsg time.Time
+
+// newClaimEventMsg converts a stream event into a claimEventMsg. Events that
+// are not claims or releases report false.
+func newClaimEventMsg(event model.Event) (claimEventMsg, bool) {
+	switch e := event.(type) {
+	case model.AgentClaimEvent:
+		return claimEventMsg{agentID: e.AgentID, patterns: e.Patterns, claim: e.Claim, release: e.Type == "agent_release"}, true
+	case model.ActivityEvent:
+		if e.Activity.Type != "claim" && e.Activity.Type != "release" {
+			return claimEventMsg{}, false
+		}
+		return claimEventMsg{agentID: e.Activity.AgentID, patterns: e.Activity.Patterns, claim: e.Activity.Claim, release: e.Activity.Type == "release"}, true
+	}
+	return claimEventMsg{}, false
+}
+
+type claimWatchModel struct {
+	client              *api.Client
+	store, repo, branch string
+	held                map[string]map[string]heldClaim // agent ID -> pattern -> claim
+	table               components.TableModel
+	now                 time.Time
+	status              string
+	loading             bool
+}
+
+func newClaimWatchModel(client *api.Client, store, repo, branch string) claimWatchModel {
+	columns := []components.TableColumn{
+		{Title: "AGENT", Width: 24},
+		{Title: "PATTERN", Width: 30},
+		{Title: "CLAIM", Width: 30},
+		{Title: "EXPIRES IN", Width: 12},
+	}
+	return claimWatchModel{
+		client:  client,
+		store:   store,
+		repo:    repo,
+		branch:  branch,
+		held:    map[string]map[string]heldClaim{},
+		table:   components.NewTable("", columns, nil),
+		now:     time.Now(),
+		status:  "connected",
+		loading: true,
+	}
+}
+
+// loadClaims lists the branch's claims in the background.
+func (m claimWatchModel) loadClaims() tea.Cmd {
+	return func() tea.Msg {
+		claims, err := m.client.ListClaims(m.store, m.repo, m.branch)
+		return claimsLoadedMsg{claims: claims, err: err}
+	}
+}
+
+func claimTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return claimTickMsg(t) })
+}
+
+func (m claimWatchModel) Init() tea.Cmd {
+	return tea.Batch(m.loadClaims(), claimTick())
+}
+
+func (m claimWatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.table.Filtering() && msg.String() != "ctrl+c" {
+			// Keys go to the table's filter input
+			break
+		}
+		switch msg.String() {
+		case "esc":
+			if m.table.FilterView() != "" {
+				// Let the table clear its filter
+				break
+			}
+			return m, tea.Quit
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "r":
+			m.loading = true
+			return m, m.loadClaims()
+		case "enter":
+			// The table would end the selection; there is nothing to select
+			return m, nil
+		}
+
+	case claimsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.status = fmt.Sprintf("failed to list claims: %v", msg.err)
+			return m, nil
+		}
+		m.held = heldClaims(msg.claims)
+		m.table = m.table.WithRows(m.rows())
+		return m, nil
+
+	case claimEventMsg:
+		// An event means the stream is up again after any drop
+		m.status = "connected"
+		m.apply(msg)
+		m.table = m.table.WithRows(m.rows())
+		if !msg.release {
+			// Events don't carry the expiry, so fetch it
+			return m, m.loadClaims()
+		}
+		return m, nil
+
+	case claimStreamStatusMsg:
+		m.status = string(msg)
+		return m, nil
+
+	case claimTickMsg:
+		m.now = time.Time(msg)
+		m.expire()
+		m.table = m.table.WithRows(m.rows())
+		return m, claimTick()
+	}
+
+	tm, cmd := m.table.Update(msg)
+	m.table = tm.(components.TableModel)
+	return m, cmd
+}
+
+// heldClaims indexes listed claims by agent and pattern.
+func heldClaims(claims []model.Claim) map[string]map[string]heldClaim {
+	held := map[string]map[string]heldClaim{}
+	for _, c := range claims {
+		var expires time.Time
+		if s := c.GetExpiresAtString(); s != nil {
+			expires, _ = time.Parse(time.RFC3339, *s)
+		}
+		for _, p := range c.Patterns {
+			if held[c.AgentID] == nil {
+				held[c.AgentID] = map[string]heldClaim{}
+			}
+			held[c.AgentID][p] = heldClaim{claim: c.Claim, expires: expires}
+		}
+	}
+	return held
+}
+
+// apply updates the held claims for a claim or release event.
+func (m *claimWatchModel) apply(msg claimEventMsg) {
+	if msg.release {
+		for _, p := range msg.patterns {
+			delete(m.held[msg.agentID], p)
+		}
+		if len(m.held[msg.agentID]) == 0 {
+			delete(m.held, msg.agentID)
+		}
+		return
+	}
+	if m.held[msg.agentID] == nil {
+		m.held[msg.agentID] = map[string]heldClaim{}
+	}
+	for _, p := range msg.patterns {
+		m.held[msg.agentID][p] = heldClaim{claim: msg.claim}
+	}
+}
+
+// expire drops claims whose expiry has passed.
+func (m *claimWatchModel) expire() {
+	for agent, patterns := range m.held {
+		for p, c := range patterns {
+			if !c.expires.IsZero() && !c.expires.After(m.now) {
+				delete(patterns, p)
+			}
+		}
+		if len(patterns) == 0 {
+			delete(m.held, agent)
+		}
+	}
+}
+
+// rows returns one table row per held pattern, sorted by agent and pattern.
+func (m claimWatchModel) rows() []table.Row {
+	var rows []table.Row
+	for agent, patterns := range m.held {
+		for p, c := range patterns {
+			expires := "-"
+			if !c.expires.IsZero() {
+				expires = c.expires.Sub(m.now).Round(time.Second).String()
+			}
+			rows = append(rows, table.Row{agent, p, c.claim, expires})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i][0] != rows[j][0] {
+			return rows[i][0] < rows[j][0]
+		}
+		return rows[i][1] < rows[j][1]
+	})
+	return rows
+}
+
+func (m claimWatchModel) View() string {
+	var b strings.Builder
+	b.WriteString(tui.TitleStyle.Render(fmt.Sprintf("Claims on %s/%s:%s", m.store, m.repo, m.branch)))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading && len(m.held) == 0:
+		b.WriteString(tui.MutedStyle.Render("Loading claims..."))
+		b.WriteString("\n\n")
+	case len(m.held) == 0:
+		b.WriteString(tui.MutedStyle.Render("No active claims"))
+		b.WriteString("\n\n")
+	default:
+		if f := m.table.FilterView(); f != "" {
+			b.WriteString(f)
+			b.WriteString("\n\n")
+		}
+		b.WriteString(m.table.Table().View())
+		b.WriteString("\n\n")
+	}
+
+	status := tui.ConnectedStyle.Render("● " + m.status)
+	if m.status != "connected" {
+		status = tui.DisconnectedStyle.Render("● " + m.status)
+	}
+	b.WriteString(status)
+	b.WriteString("  ")
+	b.WriteString(tui.HelpStyle.Render("↑↓ scroll  / filter  s sort  r refresh  q quit"))
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestClaimWatchModel(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	update := func(m tea.Model, msg tea.Msg) (claimWatchModel, tea.Cmd) {
+		m, cmd := m.Update(msg)
+		return m.(claimWatchModel), cmd
+	}
+	patterns := func(m claimWatchModel) []string {
+		var got []string
+		for _, row := range m.rows() {
+			got = append(got, row[0]+" "+row[1]+" "+row[3])
+		}
+		return got
+	}
+
+	var m tea.Model = newClaimWatchModel(nil, "s", "r", "main")
+	cm, _ := update(m, claimTickMsg(now))
+	cm, _ = update(cm, claimsLoadedMsg{claims: []model.Claim{
+		{AgentID: "b", Patterns: []string{"*.ts"}, ExpiresAt: now.Add(90 * time.Second).Format(time.RFC3339)},
+		{AgentID: "a", Patterns: []string{"docs/*", "*.go"}, ExpiresAt: now.Add(2 * time.Second).Format(time.RFC3339)},
+	}})
+	want := []string{"a *.go 2s", "a docs/* 2s", "b *.ts 1m30s"}
+	if got := patterns(cm); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after load rows = %v, want %v", got, want)
+	}
+
+	// A claim event adds the patterns and refetches for the expiry
+	cm, cmd := update(cm, claimEventMsg{agentID: "c", patterns: []string{"Makefile"}})
+	if cmd == nil {
+		t.Error("claim event should refetch claims")
+	}
+	want = []string{"a *.go 2s", "a docs/* 2s", "b *.ts 1m30s", "c Makefile -"}
+	if got := patterns(cm); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after claim rows = %v, want %v", got, want)
+	}
+
+	// A release removes only the released patterns
+	cm, _ = update(cm, claimEventMsg{agentID: "a", patterns: []string{"docs/*"}, release: true})
+	cm, _ = update(cm, claimEventMsg{agentID: "c", patterns: []string{"Makefile"}, release: true})
+	want = []string{"a *.go 2s", "b *.ts 1m30s"}
+	if got := patterns(cm); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after release rows = %v, want %v", got, want)
+	}
+
+	// Ticks count down and drop expired claims
+	cm, _ = update(cm, claimTickMsg(now.Add(5*time.Second)))
+	want = []string{"b *.ts 1m25s"}
+	if got := patterns(cm); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after expiry rows = %v, want %v", got, want)
+	}
+
+	// enter must not end the dashboard the way it ends a table selection
+	cm, _ = update(cm, tea.KeyMsg{Type: tea.KeyEnter})
+	if cm.table.Done() {
+		t.Error("enter ended the dashboard table")
+	}
+}
+
+func TestNewClaimEventMsg(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  model.Event
+		want   claimEventMsg
+		wantOK bool
+	}{
+		{
+			name:   "agent_claim",
+			event:  model.AgentClaimEvent{Type: "agent_claim", AgentID: "a", Patterns: []string{"*.go"}, Claim: "work"},
+			want:   claimEventMsg{agentID: "a", patterns: []string{"*.go"}, claim: "work"},
+			wantOK: true,
+		},
+		{
+			name:   "agent_release",
+			event:  model.AgentClaimEvent{Type: "agent_release", AgentID: "a", Patterns: []string{"*.go"}},
+			want:   claimEventMsg{agentID: "a", patterns: []string{"*.go"}, release: true},
+			wantOK: true,
+		},
+		{
+			name:   "release activity",
+			event:  model.ActivityEvent{Type: "activity", Activity: model.Activity{Type: "release", AgentID: "b", Patterns: []string{"*.ts"}}},
+			want:   claimEventMsg{agentID: "b", patterns: []string{"*.ts"}, release: true},
+			wantOK: true,
+		},
+		{
+			name:  "commit",
+			event: model.CommitEvent{Type: "commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newClaimEventMsg(tt.event)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newClaimEventMsg() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
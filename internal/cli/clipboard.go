@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+)
+
+// copyToClipboard copies a secret to the system clipboard so it stays out of
+// scrollback. When no clipboard is available (e.g. headless CI) the value is
+// printed instead, with a warning.
+func copyToClipboard(label, value string) {
+	if err := clipboard.WriteAll(value); err != nil {
+		warn(fmt.Sprintf("Clipboard unavailable (%v), printing instead", err))
+		fmt.Printf("%s: %s\n", label, maskSecret(value))
+		return
+	}
+	success(fmt.Sprintf("%s copied to clipboard", label))
+}
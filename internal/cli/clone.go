@@ -1,9 +1,15 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -15,51 +21,92 @@ import (
 )
 
 func newCloneCmd() *cobra.Command {
-	var urlOnly bool
+	var urlOnly, copyURL bool
+	var depth int
+	var branch string
 
 	cmd := &cobra.Command{
-		Use:     "clone <store/repo> [directory]",
-		Short:   "Clone a repository",
-		Example: "  scraps clone mystore/myrepo\n  scraps clone mystore/myrepo ./local-dir",
+		Use:   "clone <store/repo[:branch]|url> [directory] [-- <git args>...]",
+		Short: "Clone a repository",
+		Long: `Clone a repository with git.
+
+The repository can also be given as a full clone URL, such as one copied
+from the web UI. The URL's host is used instead of the configured one, along
+with its embedded API key if it has one.
+
+A branch given with --branch or as store/repo:branch is cloned on its own,
+without the repository's other branches. --branch wins if both are given.
+
+Arguments after -- are passed through to git clone unchanged.`,
+		Example: "  scraps clone mystore/myrepo\n  scraps clone https://api.scraps.sh/stores/mystore/repos/myrepo\n  scraps clone mystore/myrepo:dev\n  scraps clone mystore/myrepo ./local-dir\n  scraps clone mystore/myrepo --depth 1\n  scraps clone mystore/myrepo -- --branch dev --single-branch\n  scraps clone mystore/myrepo --copy",
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 1 {
-				return fmt.Errorf("repository reference required\n\nUsage: scraps clone <store/repo> [directory]\n\nExample: scraps clone mystore/myrepo")
+			positional, _ := splitDashArgs(cmd, args)
+			if len(positional) < 1 {
+				return fmt.Errorf("repository reference required\n\nUsage: scraps clone <store/repo[:branch]|url> [directory]\n\nExample: scraps clone mystore/myrepo")
 			}
-			if len(args) > 2 {
-				return fmt.Errorf("too many arguments\n\nUsage: scraps clone <store/repo> [directory]")
+			if len(positional) > 2 {
+				return fmt.Errorf("too many arguments\n\nUsage: scraps clone <store/repo[:branch]|url> [directory] [-- <git args>...]")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, repo, err := parseStoreRepo(args[0])
-			if err != nil {
-				return err
+			if depth < 0 {
+				return fmt.Errorf("--depth must be a positive number")
 			}
+			args, gitArgs := splitDashArgs(cmd, args)
 
-			client, err := api.NewClientFromConfig("")
+			src, err := parseCloneSource(args[0])
 			if err != nil {
 				return err
 			}
 
+			var client *api.Client
+			if src.apiKey != "" {
+				client = api.NewClient(src.host, src.apiKey)
+			} else {
+				client, err = api.NewClientFromConfig(src.host)
+				if err != nil {
+					return err
+				}
+			}
+
+			store, repo := src.store, src.repo
 			cloneURL := client.GetCloneURL(store, repo)
 
+			if copyURL {
+				copyToClipboard("Clone URL", cloneURL)
+				return nil
+			}
+
 			if urlOnly {
-				fmt.Println(cloneURL)
+				fmt.Println(maskSecretIn(cloneURL, client.APIKey()))
 				return nil
 			}
 
+			if err := requireGit(); err != nil {
+				return err
+			}
+
 			dir := repo
 			if len(args) > 1 {
 				dir = args[1]
 			}
 
+			if branch == "" {
+				branch = src.branch
+			}
+			if quiet {
+				gitArgs = append([]string{"--quiet"}, gitArgs...)
+			}
+			cloneArgs := gitCloneArgs(cloneURL, dir, depth, branch, gitArgs)
+
 			// Interactive mode with progress
-			if isInteractive() {
-				return runCloneTUI(cloneURL, dir)
+			if isInteractive() && !quiet {
+				return runCloneTUI(cloneArgs, dir)
 			}
 
 			// Non-interactive mode
-			gitCmd := exec.Command("git", "clone", cloneURL, dir)
+			gitCmd := exec.Command("git", cloneArgs...)
 			gitCmd.Stdout = os.Stdout
 			gitCmd.Stderr = os.Stderr
 			if err := gitCmd.Run(); err != nil {
@@ -72,20 +119,91 @@ func newCloneCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&urlOnly, "url-only", false, "Print clone URL without cloning")
+	cmd.Flags().BoolVar(&copyURL, "copy", false, "Copy clone URL to the clipboard without cloning")
+	cmd.MarkFlagsMutuallyExclusive("url-only", "copy")
+	cmd.Flags().IntVar(&depth, "depth", 0, "Create a shallow clone with this many commits")
+	cmd.Flags().StringVarP(&branch, "branch", "b", "", "Clone only this branch (overrides store/repo:branch)")
 	return cmd
 }
 
+// cloneSource is the repository a clone argument refers to.
+type cloneSource struct {
+	store  string
+	repo   string
+	branch string // empty for the default branch
+	host   string // empty for the configured host
+	apiKey string // key embedded in a clone URL, if any
+}
+
+// parseCloneSource accepts either store/repo[:branch] or a full clone URL.
+func parseCloneSource(arg string) (cloneSource, error) {
+	if !strings.Contains(arg, "://") {
+		store, repo, branch, err := parseStoreRepoBranch(arg)
+		if err != nil {
+			return cloneSource{}, err
+		}
+		return cloneSource{store: store, repo: repo, branch: branch}, nil
+	}
+
+	store, repo, err := api.ParseCloneURL(arg)
+	if err != nil {
+		return cloneSource{}, err
+	}
+	// ParseCloneURL has already validated the URL
+	u, _ := url.Parse(strings.TrimSpace(arg))
+	src := cloneSource{store: store, repo: repo, host: u.Scheme + "://" + u.Host}
+	if key, ok := u.User.Password(); ok {
+		src.apiKey = key
+	}
+	return src, nil
+}
+
+// requireGit checks that git is available before attempting a clone.
+func requireGit() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is required for cloning but was not found in PATH; install git or use --url-only")
+	}
+	return nil
+}
+
+// splitDashArgs splits args into the positional arguments and those that
+// followed a literal "--".
+func splitDashArgs(cmd *cobra.Command, args []string) ([]string, []string) {
+	dash := cmd.ArgsLenAtDash()
+	if dash < 0 {
+		return args, nil
+	}
+	return args[:dash], args[dash:]
+}
+
+// gitCloneArgs builds the git argument list shared by the interactive and
+// non-interactive clone paths.
+func gitCloneArgs(cloneURL, dir string, depth int, branch string, extra []string) []string {
+	args := []string{"clone"}
+	if depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(depth))
+	}
+	if branch != "" {
+		args = append(args, "--branch", branch, "--single-branch")
+	}
+	args = append(args, extra...)
+	return append(args, "--", cloneURL, dir)
+}
+
 // cloneModel is the TUI model for cloning.
 type cloneModel struct {
-	url      string
+	args     []string
 	dir      string
 	spinner  spinner.Model
 	progress progress.Model
+	percent  float64
+	hasPct   bool // whether git has reported any progress yet
+	events   chan tea.Msg
 	state    string // "cloning", "done", "error"
 	err      error
 }
 
-func newCloneModel(url, dir string) cloneModel {
+func newCloneModel(args []string, dir string) cloneModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = tui.SpinnerStyle
@@ -93,10 +211,11 @@ func newCloneModel(url, dir string) cloneModel {
 	p := progress.New(progress.WithDefaultGradient())
 
 	return cloneModel{
-		url:      url,
+		args:     args,
 		dir:      dir,
 		spinner:  s,
 		progress: p,
+		events:   make(chan tea.Msg, 16),
 		state:    "cloning",
 	}
 }
@@ -105,17 +224,97 @@ type cloneCompleteMsg struct {
 	err error
 }
 
+// cloneProgressMsg reports how far git is through receiving objects (0-1).
+type cloneProgressMsg float64
+
+// cloneProgressRe matches the percentage in git's "Receiving objects" lines.
+var cloneProgressRe = regexp.MustCompile(`Receiving objects:\s+(\d+)%`)
+
 func (m cloneModel) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
 		func() tea.Msg {
-			gitCmd := exec.Command("git", "clone", m.url, m.dir)
-			err := gitCmd.Run()
-			return cloneCompleteMsg{err: err}
+			runClone(m.args, m.events)
+			return nil
 		},
+		waitForCloneEvent(m.events),
 	)
 }
 
+// runClone runs git clone, forwarding progress parsed from its stderr and
+// finally the completion message to events.
+func runClone(args []string, events chan<- tea.Msg) {
+	// git only reports progress to a terminal unless asked explicitly
+	args = append([]string{args[0], "--progress"}, args[1:]...)
+	gitCmd := exec.Command("git", args...)
+
+	stderr, err := gitCmd.StderrPipe()
+	if err != nil {
+		events <- cloneCompleteMsg{err: err}
+		return
+	}
+	if err := gitCmd.Start(); err != nil {
+		events <- cloneCompleteMsg{err: err}
+		return
+	}
+
+	var lastLine string
+	scanner := bufio.NewScanner(stderr)
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		lastLine = line
+		if pct, ok := parseCloneProgress(line); ok {
+			events <- cloneProgressMsg(pct)
+		}
+	}
+
+	err = gitCmd.Wait()
+	if err != nil && lastLine != "" {
+		err = fmt.Errorf("%w: %s", err, lastLine)
+	}
+	events <- cloneCompleteMsg{err: err}
+}
+
+// waitForCloneEvent returns a command that delivers the next clone event.
+func waitForCloneEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+// parseCloneProgress extracts the "Receiving objects" percentage from a line
+// of git progress output as a fraction between 0 and 1.
+func parseCloneProgress(line string) (float64, bool) {
+	match := cloneProgressRe.FindStringSubmatch(line)
+	if match == nil {
+		return 0, false
+	}
+	pct, err := strconv.Atoi(match[1])
+	if err != nil || pct > 100 {
+		return 0, false
+	}
+	return float64(pct) / 100, true
+}
+
+// scanProgressLines is a bufio.SplitFunc that splits on both '\n' and the
+// '\r' git uses to redraw progress lines in place.
+func scanProgressLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
 func (m cloneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -124,6 +323,11 @@ func (m cloneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
+	case cloneProgressMsg:
+		m.percent = float64(msg)
+		m.hasPct = true
+		return m, waitForCloneEvent(m.events)
+
 	case cloneCompleteMsg:
 		if msg.err != nil {
 			m.state = "error"
@@ -147,6 +351,9 @@ func (m cloneModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m cloneModel) View() string {
 	switch m.state {
 	case "cloning":
+		if m.hasPct {
+			return m.progress.ViewAs(m.percent) + fmt.Sprintf(" Cloning to %s...", m.dir)
+		}
 		return m.spinner.View() + fmt.Sprintf(" Cloning to %s...", m.dir)
 	case "done":
 		return tui.SuccessStyle.Render("✓") + fmt.Sprintf(" Cloned to %s", m.dir)
@@ -156,8 +363,8 @@ func (m cloneModel) View() string {
 	return ""
 }
 
-func runCloneTUI(url, dir string) error {
-	m := newCloneModel(url, dir)
+func runCloneTUI(args []string, dir string) error {
+	m := newCloneModel(args, dir)
 	p := tea.NewProgram(m)
 	finalModel, err := p.Run()
 	if err != nil {
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+)
+
+// completeBranchRef returns a ValidArgsFunction that completes the branch in
+// a "store/repo:branch" reference. suffix is appended to each suggestion
+// (e.g. ":" when a path follows the branch). Any failure, such as being
+// logged out or offline, yields no suggestions rather than an error.
+func completeBranchRef(suffix string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		storeRepo, partial, ok := strings.Cut(toComplete, ":")
+		if !ok || strings.Contains(partial, ":") {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		store, repo, err := parseStoreRepo(storeRepo)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		client, err := api.NewClientFromConfig("")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		branches, err := client.ListBranches(store, repo)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var suggestions []string
+		for _, b := range branches {
+			if strings.HasPrefix(b.Name, partial) {
+				suggestions = append(suggestions, storeRepo+":"+b.Name+suffix)
+			}
+		}
+
+		directive := cobra.ShellCompDirectiveNoFileComp
+		if suffix != "" {
+			directive |= cobra.ShellCompDirectiveNoSpace
+		}
+		return suggestions, directive
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestCompleteBranchRef(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]string{{"name": "main"}, {"name": "feature-x"}, {"name": "fix"}})
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+
+	tests := []struct {
+		name       string
+		suffix     string
+		toComplete string
+		want       []string
+	}{
+		{name: "all branches", toComplete: "s/r:", want: []string{"s/r:main", "s/r:feature-x", "s/r:fix"}},
+		{name: "prefix", toComplete: "s/r:f", want: []string{"s/r:feature-x", "s/r:fix"}},
+		{name: "with suffix", suffix: ":", toComplete: "s/r:m", want: []string{"s/r:main:"}},
+		{name: "no colon yet", toComplete: "s/r", want: nil},
+		{name: "path already started", toComplete: "s/r:main:src", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := completeBranchRef(tt.suffix)(nil, nil, tt.toComplete)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("completeBranchRef(%q) = %v, want %v", tt.toComplete, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteBranchRefOffline(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", "http://127.0.0.1:1")
+	t.Setenv("SCRAPS_API_KEY", "")
+
+	got, _ := completeBranchRef("")(nil, nil, "s/r:")
+	if got != nil {
+		t.Errorf("completeBranchRef() = %v, want no suggestions when logged out", got)
+	}
+}
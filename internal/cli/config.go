@@ -2,6 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/morrisclay/scraps-cli/internal/config"
@@ -9,12 +13,20 @@ import (
 
 func newConfigCmd() *cobra.Command {
 	var host, outputFormat string
-	var show bool
+	var show, edit bool
 
 	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "View or update CLI configuration",
+		Long: `View or update CLI configuration.
+
+Use the get, set and unset subcommands to work with individual keys:
+  ` + strings.Join(config.Keys(), "\n  "),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if edit {
+				return editConfig()
+			}
+
 			// Show config if --show or no flags
 			if show || (host == "" && outputFormat == "") {
 				cfg, err := config.LoadConfig()
@@ -22,11 +34,15 @@ func newConfigCmd() *cobra.Command {
 					return err
 				}
 
-				if config.GetOutputFormat() == "json" {
-					outputJSON(cfg)
+				if isStructuredOutput() {
+					if err := outputStructured(cfg); err != nil {
+						return err
+					}
 				} else {
-					fmt.Printf("default_host:  %s\n", cfg.DefaultHost)
-					fmt.Printf("output_format: %s\n", cfg.OutputFormat)
+					for _, key := range config.Keys() {
+						value, _ := config.GetValue(key)
+						fmt.Printf("%-24s %s\n", key+":", value)
+					}
 				}
 				return nil
 			}
@@ -36,12 +52,13 @@ func newConfigCmd() *cobra.Command {
 				if err := config.SetHost(host); err != nil {
 					return fmt.Errorf("failed to set host: %w", err)
 				}
+				host, _ = config.NormalizeHost(host)
 				success(fmt.Sprintf("Default host set to %s", host))
 			}
 
 			if outputFormat != "" {
-				if outputFormat != "table" && outputFormat != "json" {
-					return fmt.Errorf("output format must be 'table' or 'json'")
+				if !config.IsValidOutputFormat(outputFormat) {
+					return fmt.Errorf("output format must be one of: %s", strings.Join(config.OutputFormats, ", "))
 				}
 				if err := config.SetOutputFormat(outputFormat); err != nil {
 					return fmt.Errorf("failed to set output format: %w", err)
@@ -54,8 +71,192 @@ func newConfigCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&host, "host", "", "Set default host")
-	cmd.Flags().StringVar(&outputFormat, "output", "", "Set output format (table, json)")
+	cmd.Flags().StringVar(&outputFormat, "output", "", "Set output format (table, json, yaml, csv, tsv)")
 	cmd.Flags().BoolVar(&show, "show", false, "Show current configuration")
+	cmd.Flags().BoolVar(&edit, "edit", false, "Open config.json in $EDITOR")
+	cmd.MarkFlagsMutuallyExclusive("edit", "show")
+
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigUnsetCmd())
+	cmd.AddCommand(newConfigDoctorCmd())
+
+	return cmd
+}
+
+// editConfig opens config.json in the user's editor, creating it with
+// defaults first, and validates the result. An invalid file is reported but
+// left exactly as the user saved it.
+func editConfig() error {
+	path, err := config.EnsureConfigFile()
+	if err != nil {
+		return err
+	}
+	if err := openInEditor(path); err != nil {
+		return err
+	}
+
+	issues, err := config.ValidateConfigFile()
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if issue.Key != "" {
+			warn(fmt.Sprintf("%s: %s", issue.Key, issue.Message))
+		} else {
+			warn(issue.Message)
+		}
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%s has %d problem(s); the file was left as saved", path, len(issues))
+	}
+
+	success(fmt.Sprintf("Saved %s", path))
+	return nil
+}
+
+func newConfigGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "get <key>",
+		Short:   "Print the value of a configuration key",
+		Example: "  scraps config get default_host\n  HOST=$(scraps config get default_host)",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("config key required\n\nUsage: scraps config get <key>\n\nValid keys: %s", strings.Join(config.Keys(), ", "))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := config.GetValue(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+	return cmd
+}
 
+func newConfigSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set <key> <value>",
+		Short:   "Set a configuration key",
+		Example: "  scraps config set output_format json\n  scraps config set request_timeout_seconds 60\n  scraps config set agent_id my-agent",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("config key and value required\n\nUsage: scraps config set <key> <value>\n\nValid keys: %s", strings.Join(config.Keys(), ", "))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.SetValue(args[0], args[1]); err != nil {
+				return err
+			}
+			success(fmt.Sprintf("%s set to %s", args[0], args[1]))
+			return nil
+		},
+	}
 	return cmd
 }
+
+func newConfigUnsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "unset <key>",
+		Short:   "Reset a configuration key to its default",
+		Example: "  scraps config unset default_host",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("config key required\n\nUsage: scraps config unset <key>\n\nValid keys: %s", strings.Join(config.Keys(), ", "))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.UnsetValue(args[0]); err != nil {
+				return err
+			}
+			value, _ := config.GetValue(args[0])
+			success(fmt.Sprintf("%s reset to %s", args[0], value))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newConfigDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check configuration and credentials for problems",
+		Long: `Check configuration and credentials for problems.
+
+Reports unknown keys and invalid values in config.json, and checks that every
+host in credentials.json is a well-formed, reachable URL. Exits non-zero if
+any problems are found.`,
+		Example: "  scraps config doctor",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			issues, err := config.ValidateConfigFile()
+			if err != nil {
+				return err
+			}
+			issues = append(issues, checkCredentialHosts()...)
+
+			if isStructuredOutput() {
+				if issues == nil {
+					issues = []config.Issue{}
+				}
+				if err := outputStructured(issues); err != nil {
+					return err
+				}
+			} else {
+				for _, issue := range issues {
+					if issue.Key != "" {
+						warn(fmt.Sprintf("%s: %s: %s", issue.File, issue.Key, issue.Message))
+					} else {
+						warn(fmt.Sprintf("%s: %s", issue.File, issue.Message))
+					}
+				}
+			}
+
+			if len(issues) > 0 {
+				return fmt.Errorf("found %d configuration problem(s)", len(issues))
+			}
+			if !isStructuredOutput() {
+				success("No configuration problems found")
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// checkCredentialHosts verifies that every host with saved credentials is a
+// well-formed URL that answers HTTP requests.
+func checkCredentialHosts() []config.Issue {
+	creds, err := config.LoadCredentials()
+	if err != nil {
+		return []config.Issue{{File: "credentials.json", Message: fmt.Sprintf("cannot read credentials: %v", err)}}
+	}
+
+	hosts := make([]string, 0, len(creds))
+	for host := range creds {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var issues []config.Issue
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	for _, host := range hosts {
+		if err := config.CheckHostURL(host); err != nil {
+			issues = append(issues, config.Issue{File: "credentials.json", Key: host, Message: err.Error()})
+			continue
+		}
+		resp, err := httpClient.Get(host)
+		if err != nil {
+			issues = append(issues, config.Issue{File: "credentials.json", Key: host, Message: fmt.Sprintf("host is unreachable: %v", err)})
+			continue
+		}
+		resp.Body.Close()
+	}
+	return issues
+}
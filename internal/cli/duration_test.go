@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "days", s: "30d", want: 30 * 24 * time.Hour},
+		{name: "weeks", s: "1w", want: 7 * 24 * time.Hour},
+		{name: "hours", s: "12h", want: 12 * time.Hour},
+		{name: "mixed", s: "1w2d", want: 9 * 24 * time.Hour},
+		{name: "days and hours", s: "1d12h", want: 36 * time.Hour},
+		{name: "minutes", s: "90m", want: 90 * time.Minute},
+		{name: "empty", s: "", wantErr: true},
+		{name: "garbage", s: "soon", wantErr: true},
+		{name: "bare number", s: "7", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDuration(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseDuration(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
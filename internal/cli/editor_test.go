@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		visual string
+		editor string
+		want   []string
+	}{
+		{name: "visual wins", visual: "code -w", editor: "nano", want: []string{"code", "-w"}},
+		{name: "editor", editor: "nano", want: []string{"nano"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("VISUAL", tt.visual)
+			t.Setenv("EDITOR", tt.editor)
+			if got := editorCommand(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("editorCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditConfigKeepsInvalidFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("SCRAPS_CONFIG_DIR", dir)
+
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	script := "#!/bin/sh\nprintf '{\"output_format\": ' > \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", editor)
+
+	if err := editConfig(); err == nil {
+		t.Fatal("editConfig() error = nil, want invalid JSON error")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"output_format": ` {
+		t.Errorf("config.json = %q, want the edited content left in place", data)
+	}
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+)
+
+// Exit codes, so scripts can tell failure classes apart. Keep the list in
+// rootCmd's help in sync.
+const (
+	exitOK       = 0
+	exitError    = 1 // any failure not covered below
+	exitAuth     = 2 // not logged in, or the API rejected the credentials
+	exitNotFound = 3 // the store, repo, file or other resource does not exist
+	exitConflict = 4 // the request conflicts with current state (HTTP 409, claim conflicts)
+	exitNetwork  = 5 // the API could not be reached
+)
+
+// exitCode maps an error returned by a command to the process exit code.
+func exitCode(err error) int {
+	code, _ := classifyError(err)
+	return code
+}
+
+// classifyError returns the exit code for err and a short name for its
+// class, used as the code in structured error output.
+func classifyError(err error) (int, string) {
+	if err == nil {
+		return exitOK, ""
+	}
+	if errors.Is(err, api.ErrNotLoggedIn) {
+		return exitAuth, "not_logged_in"
+	}
+	if errors.Is(err, errClaimConflict) {
+		return exitConflict, "conflict"
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.IsUnauthorized():
+			return exitAuth, "unauthorized"
+		case apiErr.IsForbidden():
+			return exitAuth, "forbidden"
+		case apiErr.IsNotFound():
+			return exitNotFound, "not_found"
+		case apiErr.IsConflict():
+			return exitConflict, "conflict"
+		case apiErr.IsRateLimited():
+			return exitError, "rate_limited"
+		}
+		return exitError, "api_error"
+	}
+
+	// Dial, DNS and timeout failures from the HTTP client
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return exitNetwork, "network"
+	}
+	return exitError, "error"
+}
+
+// errorEnvelope is how a failed command reports its error in structured
+// output mode.
+type errorEnvelope struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Message   string `json:"message"`
+	Code      string `json:"code"`
+	Status    int    `json:"status,omitempty"`     // HTTP status, for API errors
+	RequestID string `json:"request_id,omitempty"` // server request ID, for API errors
+	ExitCode  int    `json:"exit_code"`
+}
+
+// newErrorEnvelope describes err for structured output.
+func newErrorEnvelope(err error) errorEnvelope {
+	exit, code := classifyError(err)
+	detail := errorDetail{Message: err.Error(), Code: code, ExitCode: exit}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		detail.Status = apiErr.StatusCode
+		detail.RequestID = apiErr.RequestID
+	}
+	return errorEnvelope{Error: detail}
+}
+
+// reportError prints the error a command failed with. In structured output
+// mode it is written to stdout as an {"error": {...}} object, since that is
+// where scripts are reading; otherwise it is a line on stderr.
+func reportError(err error) {
+	var reported *reportedError
+	if errors.As(err, &reported) {
+		return
+	}
+
+	// --output may have been parsed even if a later flag failed, in which
+	// case PersistentPreRunE never recorded it
+	if outputFormat != "" {
+		os.Setenv("SCRAPS_OUTPUT_FORMAT", outputFormat)
+	}
+
+	// A delimited error record would be read as data, so csv and tsv
+	// report errors as text like the table format
+	if isStructuredOutput() && !isDelimitedOutput() {
+		if outErr := outputStructured(newErrorEnvelope(err)); outErr == nil {
+			return
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Error:", err)
+}
+
+// causedError is an error with its own message that still unwraps to the
+// error behind it.
+type causedError struct {
+	msg   string
+	cause error
+}
+
+func (e *causedError) Error() string { return e.msg }
+func (e *causedError) Unwrap() error { return e.cause }
+
+// reportedError is returned by a command that has already described the
+// failure in its own output. Nothing more is printed, but the exit code still
+// reflects the cause.
+type reportedError struct {
+	cause error
+}
+
+func (e *reportedError) Error() string { return e.cause.Error() }
+func (e *reportedError) Unwrap() error { return e.cause }
+
+// describeError replaces cause's message while keeping it reachable through
+// errors.As, so the exit code still reflects the API status behind a
+// friendlier message.
+func describeError(cause error, format string, args ...any) error {
+	return &causedError{msg: fmt.Sprintf(format, args...), cause: cause}
+}
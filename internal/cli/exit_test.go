@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitOK},
+		{name: "generic", err: errors.New("boom"), want: exitError},
+		{name: "not logged in", err: fmt.Errorf("%w to https://api.scraps.sh", api.ErrNotLoggedIn), want: exitAuth},
+		{name: "unauthorized", err: &api.APIError{StatusCode: 401}, want: exitAuth},
+		{name: "forbidden", err: &api.APIError{StatusCode: 403}, want: exitAuth},
+		{name: "not found", err: &api.APIError{StatusCode: 404}, want: exitNotFound},
+		{name: "conflict", err: &api.APIError{StatusCode: 409}, want: exitConflict},
+		{name: "server error", err: &api.APIError{StatusCode: 500}, want: exitError},
+		{name: "wrapped not found", err: fmt.Errorf("branch 'x' not found: %w", &api.APIError{StatusCode: 404}), want: exitNotFound},
+		{name: "described forbidden", err: describeError(&api.APIError{StatusCode: 403}, "only the owner can do that"), want: exitAuth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeNetworkError(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	// A server that is already closed refuses connections
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := api.NewClient(server.URL, "test-key")
+	_, err := client.GetUser()
+	if err == nil {
+		t.Fatal("GetUser() against a closed server succeeded")
+	}
+	if got := exitCode(err); got != exitNetwork {
+		t.Errorf("exitCode(%v) = %d, want %d", err, got, exitNetwork)
+	}
+}
+
+func TestDescribeError(t *testing.T) {
+	cause := &api.APIError{StatusCode: 404, Message: "no such token"}
+	err := describeError(cause, "token not found: %s", "tok_1")
+
+	if err.Error() != "token not found: tok_1" {
+		t.Errorf("Error() = %q, want the new message", err.Error())
+	}
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr != cause {
+		t.Error("describeError() does not unwrap to its cause")
+	}
+}
+
+func TestReportErrorJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-42")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "repository not found"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+	cmd := newRepoCmd()
+	cmd.SetArgs([]string{"show", "s/missing"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	var err error
+	out := captureStdout(t, func() {
+		err = cmd.Execute()
+		if err != nil {
+			reportError(err)
+		}
+	})
+	if err == nil {
+		t.Fatal("repo show of a missing repo succeeded")
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal([]byte(out), &envelope); err != nil {
+		t.Fatalf("stdout is not a JSON error envelope: %v\n%s", err, out)
+	}
+	got := envelope.Error
+	if got.Code != "not_found" || got.Status != http.StatusNotFound || got.ExitCode != exitNotFound {
+		t.Errorf("error envelope = %+v, want not_found/404/%d", got, exitNotFound)
+	}
+	if got.RequestID != "req-42" {
+		t.Errorf("request_id = %q, want req-42", got.RequestID)
+	}
+	if !strings.Contains(got.Message, "repository not found") {
+		t.Errorf("message = %q, want the API message", got.Message)
+	}
+}
+
+func TestReportErrorText(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "table")
+
+	out := captureStdout(t, func() { reportError(errors.New("boom")) })
+	if out != "" {
+		t.Errorf("text mode wrote %q to stdout, want nothing", out)
+	}
+}
@@ -0,0 +1,429 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+// exportFormatVersion is the version of the export document. The format is
+// stable: new fields may be added, but existing fields keep their names and
+// meaning until the version changes.
+const exportFormatVersion = 1
+
+// exportConcurrency bounds the number of list requests export has in flight
+// at once.
+const exportConcurrency = 8
+
+// accountExport is the document written by scraps export.
+type accountExport struct {
+	Version    int           `json:"version"`
+	ExportedAt string        `json:"exported_at"`
+	Host       string        `json:"host"`
+	Stores     []storeExport `json:"stores"`
+	Errors     []exportError `json:"errors,omitempty"`
+}
+
+type storeExport struct {
+	storeInfo
+	Repos []repoExport `json:"repos"`
+}
+
+// storeInfo is a store without its repos, as in a streamed store record.
+type storeInfo struct {
+	Slug      string         `json:"slug"`
+	Role      string         `json:"role,omitempty"` // the exporting user's role
+	CreatedAt string         `json:"created_at"`
+	Members   []memberExport `json:"members"`
+}
+
+type repoExport struct {
+	Name          string         `json:"name"`
+	DefaultBranch string         `json:"default_branch,omitempty"`
+	Archived      bool           `json:"archived"`
+	CreatedAt     string         `json:"created_at"`
+	Collaborators []memberExport `json:"collaborators"`
+}
+
+// memberExport is a store member or repository collaborator.
+type memberExport struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	AddedAt  string `json:"added_at"`
+}
+
+// exportError records a part of the account that could not be listed. Path
+// names it, e.g. "mystore/myrepo collaborators".
+type exportError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// Records of a streamed export, one per line and told apart by "type".
+type (
+	exportHeaderRecord struct {
+		Type       string `json:"type"` // "export"
+		Version    int    `json:"version"`
+		ExportedAt string `json:"exported_at"`
+		Host       string `json:"host"`
+	}
+	exportStoreRecord struct {
+		Type string `json:"type"` // "store"
+		storeInfo
+	}
+	exportRepoRecord struct {
+		Type  string `json:"type"` // "repo"
+		Store string `json:"store"`
+		repoExport
+	}
+	exportErrorRecord struct {
+		Type string `json:"type"` // "error"
+		exportError
+	}
+)
+
+func newExportCmd() *cobra.Command {
+	var stores []string
+	var outputFile string
+	var stream bool
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export your stores, repos and members as JSON",
+		Long: `Export the structure of your account as a single JSON document.
+
+The export lists each store with its members and repositories, and each
+repository with its collaborators. File contents are not included; clone the
+repositories for those.
+
+The document format is stable. It has a "version" field, currently 1, and
+within a version fields are only ever added:
+
+  {
+    "version": 1,
+    "exported_at": "<RFC3339 time>",
+    "host": "<API host>",
+    "stores": [{
+      "slug": "...", "role": "...", "created_at": "...",
+      "members": [{"username": "...", "role": "...", "added_at": "..."}],
+      "repos": [{
+        "name": "...", "default_branch": "...", "archived": false,
+        "created_at": "...",
+        "collaborators": [{"username": "...", "role": "...", "added_at": "..."}]
+      }]
+    }],
+    "errors": [{"path": "mystore members", "error": "..."}]
+  }
+
+Parts of the account that cannot be listed, such as the members of a store
+you are not an admin of, are recorded under "errors" and the rest is still
+exported. The command then exits non-zero.
+
+With --stream, the export is written as newline-delimited JSON instead, one
+record per line as it is found, so large accounts can be processed
+incrementally. Every record has a "type":
+
+  {"type": "export", "version": 1, "exported_at": "...", "host": "..."}
+  {"type": "store", "slug": "...", "role": "...", "created_at": "...", "members": [...]}
+  {"type": "repo", "store": "<store slug>", "name": "...", "default_branch": "...",
+   "archived": false, "created_at": "...", "collaborators": [...]}
+  {"type": "error", "path": "...", "error": "..."}
+
+The header comes first. Stores are exported one at a time, so each store's
+record is followed by its repos before the next store begins; repos within
+a store are in no particular order.`,
+		Example: "  scraps export > account.json\n  scraps export --stores mystore,other --output-file backup.json\n  scraps export --stream | jq -c 'select(.type == \"repo\")'",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			if stream {
+				return runExportStream(client, stores, outputFile)
+			}
+
+			doc, err := withLoading("Exporting account…", func() (*accountExport, error) {
+				return exportAccount(client, stores)
+			})
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(doc, "", "  ")
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+
+			if outputFile != "" {
+				if err := os.WriteFile(outputFile, data, 0o600); err != nil {
+					return fmt.Errorf("failed to write %s: %w", outputFile, err)
+				}
+				success(fmt.Sprintf("Exported %d store(s) to %s", len(doc.Stores), outputFile))
+			} else {
+				os.Stdout.Write(data)
+			}
+			return exportIncomplete(doc.Errors)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&stores, "stores", nil, "Only export these stores (comma-separated slugs)")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the export to this file instead of stdout")
+	cmd.Flags().BoolVar(&stream, "stream", false, "Write newline-delimited JSON records as they are found")
+	return cmd
+}
+
+// exportIncomplete warns about the parts of an export that failed and
+// returns an error for the exit code, or nil if there were none. The export
+// itself records the failures.
+func exportIncomplete(errs []exportError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	for _, e := range errs {
+		warn(fmt.Sprintf("Could not export %s: %s", e.Path, e.Error))
+	}
+	return &reportedError{cause: fmt.Errorf("export incomplete: %d part(s) could not be listed", len(errs))}
+}
+
+// exportStores lists the account's stores, or only those named in only,
+// sorted by slug.
+func exportStores(client *api.Client, only []string) ([]model.Store, error) {
+	stores, err := client.ListStores()
+	if err != nil {
+		return nil, err
+	}
+	if len(only) > 0 {
+		for _, slug := range only {
+			if !slices.ContainsFunc(stores, func(s model.Store) bool { return s.Slug == slug }) {
+				return nil, fmt.Errorf("store %q not found among your stores", slug)
+			}
+		}
+		stores = slices.DeleteFunc(stores, func(s model.Store) bool { return !slices.Contains(only, s.Slug) })
+	}
+	sort.Slice(stores, func(i, j int) bool { return stores[i].Slug < stores[j].Slug })
+	return stores, nil
+}
+
+// exportAccount gathers the account's stores, or only those named in only,
+// with their members, repos and collaborators. Listing the stores must
+// succeed; any other failure is recorded in the document's errors.
+func exportAccount(client *api.Client, only []string) (*accountExport, error) {
+	stores, err := exportStores(client, only)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &exportCollector{
+		doc: &accountExport{
+			Version:    exportFormatVersion,
+			ExportedAt: time.Now().UTC().Format(time.RFC3339),
+			Host:       client.Host(),
+			Stores:     []storeExport{},
+		},
+		index: map[string]int{},
+	}
+	walkExport(client, stores, c, false)
+
+	doc := c.doc
+	sort.Slice(doc.Stores, func(i, j int) bool { return doc.Stores[i].Slug < doc.Stores[j].Slug })
+	for _, store := range doc.Stores {
+		sort.Slice(store.Repos, func(i, j int) bool { return store.Repos[i].Name < store.Repos[j].Name })
+	}
+	sort.Slice(doc.Errors, func(i, j int) bool { return doc.Errors[i].Path < doc.Errors[j].Path })
+	return doc, nil
+}
+
+// runExportStream writes the export as NDJSON records to outputFile, or
+// stdout if it is empty, as walkExport finds them.
+func runExportStream(client *api.Client, only []string, outputFile string) error {
+	stores, err := exportStores(client, only)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if outputFile != "" {
+		out, err = os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", outputFile, err)
+		}
+		defer out.Close()
+	}
+
+	s := &exportStreamer{enc: json.NewEncoder(out)}
+	s.write(exportHeaderRecord{
+		Type:       "export",
+		Version:    exportFormatVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Host:       client.Host(),
+	})
+	walkExport(client, stores, s, true)
+	if s.err != nil {
+		return fmt.Errorf("failed to write export: %w", s.err)
+	}
+
+	if outputFile != "" {
+		success(fmt.Sprintf("Exported %d store(s) to %s", len(stores), outputFile))
+	}
+	return exportIncomplete(s.errors)
+}
+
+// exportSink receives the parts of an account as walkExport finds them.
+// Its methods may be called from several goroutines at once.
+type exportSink interface {
+	store(store storeInfo)
+	repo(store string, repo repoExport)
+	fail(e exportError)
+}
+
+// walkExport lists the members, repos and collaborators of each store,
+// passing them to sink with bounded concurrency. A store is always passed
+// before its repos. If grouped, each store is finished before the next is
+// started; otherwise stores are walked concurrently.
+func walkExport(client *api.Client, stores []model.Store, sink exportSink, grouped bool) {
+	sem := make(chan struct{}, exportConcurrency)
+	var wg sync.WaitGroup
+	for _, s := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			walkExportStore(client, s, sem, sink)
+		}()
+		if grouped {
+			wg.Wait()
+		}
+	}
+	wg.Wait()
+}
+
+// walkExportStore passes one store and its repos to sink. sem is held only
+// around each request, never while waiting for other workers.
+func walkExportStore(client *api.Client, s model.Store, sem chan struct{}, sink exportSink) {
+	store := storeInfo{Slug: s.Slug, Role: s.Role, CreatedAt: s.CreatedAt, Members: []memberExport{}}
+	var repos []model.Repository
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		sem <- struct{}{}
+		members, err := client.ListStoreMembers(s.Slug)
+		<-sem
+		if err != nil {
+			sink.fail(exportError{Path: s.Slug + " members", Error: err.Error()})
+			return
+		}
+		for _, m := range members {
+			store.Members = append(store.Members, memberExport{Username: m.Username, Role: m.Role, AddedAt: m.CreatedAt})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		sem <- struct{}{}
+		var err error
+		repos, err = client.ListRepos(s.Slug)
+		<-sem
+		if err != nil {
+			sink.fail(exportError{Path: s.Slug + " repos", Error: err.Error()})
+		}
+	}()
+	wg.Wait()
+	sink.store(store)
+
+	for _, r := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo := repoExport{
+				Name:          r.Name,
+				DefaultBranch: r.DefaultBranch,
+				Archived:      r.Archived,
+				CreatedAt:     r.CreatedAt,
+				Collaborators: []memberExport{},
+			}
+
+			sem <- struct{}{}
+			collabs, err := client.ListCollaborators(s.Slug, r.Name)
+			<-sem
+			if err != nil {
+				sink.fail(exportError{Path: formatStoreRepo(s.Slug, r.Name) + " collaborators", Error: err.Error()})
+			}
+			for _, c := range collabs {
+				repo.Collaborators = append(repo.Collaborators, memberExport{Username: c.Username, Role: c.Role, AddedAt: c.CreatedAt})
+			}
+			sink.repo(s.Slug, repo)
+		}()
+	}
+	wg.Wait()
+}
+
+// exportCollector is the exportSink that builds the export document.
+type exportCollector struct {
+	mu    sync.Mutex
+	doc   *accountExport
+	index map[string]int // store slug -> index in doc.Stores
+}
+
+func (c *exportCollector) store(store storeInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.index[store.Slug] = len(c.doc.Stores)
+	c.doc.Stores = append(c.doc.Stores, storeExport{storeInfo: store, Repos: []repoExport{}})
+}
+
+func (c *exportCollector) repo(store string, repo repoExport) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := &c.doc.Stores[c.index[store]]
+	s.Repos = append(s.Repos, repo)
+}
+
+func (c *exportCollector) fail(e exportError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.doc.Errors = append(c.doc.Errors, e)
+}
+
+// exportStreamer is the exportSink that writes each part as an NDJSON
+// record. Only failures are kept in memory.
+type exportStreamer struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	errors []exportError
+	err    error // first write error
+}
+
+func (s *exportStreamer) write(record any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = s.enc.Encode(record)
+	}
+}
+
+func (s *exportStreamer) store(store storeInfo) {
+	s.write(exportStoreRecord{Type: "store", storeInfo: store})
+}
+
+func (s *exportStreamer) repo(store string, repo repoExport) {
+	s.write(exportRepoRecord{Type: "repo", Store: store, repoExport: repo})
+}
+
+func (s *exportStreamer) fail(e exportError) {
+	s.mu.Lock()
+	s.errors = append(s.errors, e)
+	s.mu.Unlock()
+	s.write(exportErrorRecord{Type: "error", exportError: e})
+}
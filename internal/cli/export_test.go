@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func exportServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/stores":
+			json.NewEncoder(w).Encode([]model.Store{
+				{Slug: "zeta", Role: "member", CreatedAt: "2024-02-01T00:00:00Z"},
+				{Slug: "acme", Role: "owner", CreatedAt: "2024-01-01T00:00:00Z"},
+			})
+		case "/api/v1/stores/acme/members":
+			json.NewEncoder(w).Encode([]model.StoreMember{{Username: "ada", Role: "owner", CreatedAt: "2024-01-01T00:00:00Z"}})
+		case "/api/v1/stores/zeta/members":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": "admin role required"}`))
+		case "/api/v1/stores/acme/repos":
+			json.NewEncoder(w).Encode([]model.Repository{
+				{Name: "web", DefaultBranch: "main", CreatedAt: "2024-01-02T00:00:00Z"},
+				{Name: "api", CreatedAt: "2024-01-03T00:00:00Z", Archived: true},
+			})
+		case "/api/v1/stores/zeta/repos":
+			json.NewEncoder(w).Encode([]model.Repository{})
+		case "/api/v1/stores/acme/repos/web/collaborators":
+			json.NewEncoder(w).Encode([]model.Collaborator{{Username: "bob", Role: "write", CreatedAt: "2024-01-05T00:00:00Z"}})
+		case "/api/v1/stores/acme/repos/api/collaborators":
+			json.NewEncoder(w).Encode([]model.Collaborator{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	return server
+}
+
+func TestExport(t *testing.T) {
+	exportServer(t)
+	path := filepath.Join(t.TempDir(), "export.json")
+
+	cmd := newExportCmd()
+	cmd.SetArgs([]string{"--output-file", path})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	if err == nil || exitCode(err) != exitError {
+		t.Errorf("export error = %v, want an incomplete export error", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+	var doc accountExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, data)
+	}
+
+	if doc.Version != exportFormatVersion || doc.ExportedAt == "" {
+		t.Errorf("version = %d, exported_at = %q", doc.Version, doc.ExportedAt)
+	}
+	want := []storeExport{
+		{
+			storeInfo: storeInfo{
+				Slug: "acme", Role: "owner", CreatedAt: "2024-01-01T00:00:00Z",
+				Members: []memberExport{{Username: "ada", Role: "owner", AddedAt: "2024-01-01T00:00:00Z"}},
+			},
+			Repos: []repoExport{
+				{Name: "api", Archived: true, CreatedAt: "2024-01-03T00:00:00Z", Collaborators: []memberExport{}},
+				{Name: "web", DefaultBranch: "main", CreatedAt: "2024-01-02T00:00:00Z", Collaborators: []memberExport{
+					{Username: "bob", Role: "write", AddedAt: "2024-01-05T00:00:00Z"},
+				}},
+			},
+		},
+		{
+			storeInfo: storeInfo{Slug: "zeta", Role: "member", CreatedAt: "2024-02-01T00:00:00Z", Members: []memberExport{}},
+			Repos:     []repoExport{},
+		},
+	}
+	if !reflect.DeepEqual(doc.Stores, want) {
+		t.Errorf("stores = %+v\nwant %+v", doc.Stores, want)
+	}
+	if len(doc.Errors) != 1 || doc.Errors[0].Path != "zeta members" {
+		t.Errorf("errors = %+v, want the zeta members failure", doc.Errors)
+	}
+}
+
+func TestExportStores(t *testing.T) {
+	exportServer(t)
+
+	cmd := newExportCmd()
+	cmd.SetArgs([]string{"--stores", "acme"})
+	var err error
+	out := captureStdout(t, func() { err = cmd.Execute() })
+	if err != nil {
+		t.Fatalf("export error = %v", err)
+	}
+	var doc accountExport
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, out)
+	}
+	if len(doc.Stores) != 1 || doc.Stores[0].Slug != "acme" || len(doc.Errors) != 0 {
+		t.Errorf("export = %+v, want only acme without errors", doc)
+	}
+
+	cmd = newExportCmd()
+	cmd.SetArgs([]string{"--stores", "nope"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("export --stores nope succeeded, want an error")
+	}
+}
+
+func TestExportStream(t *testing.T) {
+	exportServer(t)
+
+	cmd := newExportCmd()
+	cmd.SetArgs([]string{"--stream"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	var err error
+	out := captureStdout(t, func() { err = cmd.Execute() })
+	if err == nil {
+		t.Error("export --stream error = nil, want an incomplete export error")
+	}
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var r map[string]any
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("line is not JSON: %v\n%s", err, line)
+		}
+		records = append(records, r)
+	}
+
+	// The header comes first, and each store's repos follow its record
+	var got []string
+	for _, r := range records {
+		switch r["type"] {
+		case "export":
+			got = append(got, "export")
+		case "store":
+			got = append(got, "store "+r["slug"].(string))
+		case "repo":
+			got = append(got, "repo "+r["store"].(string))
+		case "error":
+			got = append(got, "error "+r["path"].(string))
+		}
+	}
+	want := []string{"export", "store acme", "repo acme", "repo acme", "error zeta members", "store zeta"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+
+	for _, r := range records {
+		if r["type"] == "repo" && r["name"] == "web" {
+			collabs, _ := r["collaborators"].([]any)
+			if len(collabs) != 1 {
+				t.Errorf("web collaborators = %v, want bob", r["collaborators"])
+			}
+		}
+	}
+}
@@ -1,19 +1,27 @@
 package cli
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/morrisclay/scraps-cli/internal/api"
-	"github.com/morrisclay/scraps-cli/internal/config"
 	"github.com/morrisclay/scraps-cli/internal/model"
 	"github.com/morrisclay/scraps-cli/internal/tui"
+	"github.com/morrisclay/scraps-cli/internal/tui/components"
 )
 
 func newFileCmd() *cobra.Command {
@@ -24,6 +32,10 @@ func newFileCmd() *cobra.Command {
 
 	cmd.AddCommand(newFileTreeCmd())
 	cmd.AddCommand(newFileReadCmd())
+	cmd.AddCommand(newFileDownloadCmd())
+	cmd.AddCommand(newFileGrepCmd())
+	cmd.AddCommand(newFileWriteCmd())
+	cmd.AddCommand(newFileDeleteCmd())
 
 	return cmd
 }
@@ -31,10 +43,18 @@ func newFileCmd() *cobra.Command {
 // --- File Tree Command ---
 
 func newFileTreeCmd() *cobra.Command {
+	var recursive bool
+	var depth int
+
 	cmd := &cobra.Command{
-		Use:     "tree <store/repo[:branch]> [path]",
-		Short:   "List files in a repository",
-		Example: "  scraps file tree mystore/myrepo\n  scraps file tree mystore/myrepo:main src/",
+		Use:   "tree <store/repo[:branch][#sha]> [path]",
+		Short: "List files in a repository",
+		Long: `List files in a repository.
+
+With --recursive, every subdirectory is listed too and entries are shown by
+their full path. This takes one request per directory, so it can be slow on
+large trees; use --depth to limit how far down it goes.`,
+		Example: "  scraps file tree mystore/myrepo\n  scraps file tree mystore/myrepo:main src/\n  scraps file tree mystore/myrepo -r --depth 2",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("repository reference required\n\nUsage: scraps file tree <store/repo[:branch]> [path]\n\nExample: scraps file tree mystore/myrepo")
@@ -44,28 +64,53 @@ func newFileTreeCmd() *cobra.Command {
 			}
 			return nil
 		},
+		ValidArgsFunction: completeBranchRef(""),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, repo, branch, _, err := parseStoreRepoBranchPath(args[0] + ":")
+			ref, commit := splitCommit(args[0])
+			store, repo, branch, _, err := parseStoreRepoBranchPath(ref + ":")
 			if err != nil {
 				// Try parsing as store/repo:branch
-				store, repo, branch, err = parseStoreRepoBranch(args[0])
+				store, repo, branch, err = parseStoreRepoBranch(ref)
 				if err != nil {
 					return err
 				}
 			}
+			branch = pinnedRef(branch, commit)
 
 			path := ""
 			if len(args) > 1 {
 				path = args[1]
 			}
 
+			if cmd.Flags().Changed("depth") && !recursive {
+				return fmt.Errorf("--depth requires --recursive")
+			}
+			if depth < 0 {
+				return fmt.Errorf("--depth must not be negative")
+			}
+
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
 				return err
 			}
 
+			if recursive {
+				entries, err := walkFileTree(client, store, repo, branch, path, depth)
+				if err != nil {
+					return err
+				}
+				if isStructuredOutput() && !isDelimitedOutput() {
+					return outputStructured(entries)
+				}
+				rows := make([][]string, len(entries))
+				for i, e := range entries {
+					rows[i] = []string{e.Type, e.Path, shortSHA(e.SHA)}
+				}
+				return outputTable([]string{"TYPE", "PATH", "SHA"}, rows)
+			}
+
 			// If interactive, launch tree browser
-			if isInteractive() && config.GetOutputFormat() != "json" {
+			if isInteractive() && !isStructuredOutput() {
 				return runTreeBrowser(client, store, repo, branch, path)
 			}
 
@@ -75,26 +120,129 @@ func newFileTreeCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(entries)
+			if isStructuredOutput() && !isDelimitedOutput() {
+				if err := outputStructured(entries); err != nil {
+					return err
+				}
 			} else {
 				headers := []string{"TYPE", "NAME", "SHA"}
 				rows := make([][]string, len(entries))
 				for i, e := range entries {
-					sha := ""
-					if e.SHA != "" {
-						sha = e.SHA[:8]
-					}
-					rows[i] = []string{e.Type, e.Name, sha}
+					rows[i] = []string{e.Type, e.Name, shortSHA(e.SHA)}
+				}
+				if err := outputTable(headers, rows); err != nil {
+					return err
 				}
-				outputTable(headers, rows)
 			}
 			return nil
 		},
 	}
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "List subdirectories too, by full path")
+	cmd.Flags().IntVar(&depth, "depth", 0, "With --recursive, how many levels to list (0 for no limit)")
+
 	return cmd
 }
 
+// treeWalkConcurrency bounds the number of GetFileTree requests that
+// walkFileTree has in flight at once.
+const treeWalkConcurrency = 8
+
+// treePathEntry is a file tree entry identified by its path from the
+// repository root.
+type treePathEntry struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	SHA  string `json:"sha,omitempty"`
+}
+
+// treeNode is a directory listing gathered by walkFileTree.
+type treeNode struct {
+	entries  []model.FileTreeEntry
+	children []*treeNode // per entry, set for subdirectories that were listed
+}
+
+// walkFileTree lists root and its subdirectories, returning every entry
+// depth-first with directories before their contents. Directories are listed
+// concurrently. depth limits how many levels are listed; 0 means no limit.
+func walkFileTree(client *api.Client, store, repo, branch, root string, depth int) ([]treePathEntry, error) {
+	root = strings.Trim(root, "/")
+	sem := make(chan struct{}, treeWalkConcurrency)
+
+	var mu sync.Mutex
+	var firstErr error
+
+	var wg sync.WaitGroup
+	var list func(node *treeNode, dir string, level int)
+	list = func(node *treeNode, dir string, level int) {
+		defer wg.Done()
+
+		sem <- struct{}{}
+		entries, err := client.GetFileTree(store, repo, branch, dir)
+		<-sem
+		if err != nil {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to list %s: %w", displayTreePath(dir), err)
+			}
+			mu.Unlock()
+			return
+		}
+
+		// Each worker fills in only its own node, so no locking is needed
+		node.entries = entries
+		node.children = make([]*treeNode, len(entries))
+		if depth > 0 && level >= depth {
+			return
+		}
+		for i, e := range entries {
+			if e.Type != "tree" {
+				continue
+			}
+			node.children[i] = &treeNode{}
+			wg.Add(1)
+			go list(node.children[i], joinTreePath(dir, e.Name), level+1)
+		}
+	}
+
+	top := &treeNode{}
+	wg.Add(1)
+	go list(top, root, 1)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var result []treePathEntry
+	var flatten func(node *treeNode, prefix string)
+	flatten = func(node *treeNode, prefix string) {
+		for i, e := range node.entries {
+			path := joinTreePath(prefix, e.Name)
+			result = append(result, treePathEntry{Type: e.Type, Path: path, SHA: e.SHA})
+			if node.children[i] != nil {
+				flatten(node.children[i], path)
+			}
+		}
+	}
+	flatten(top, root)
+	return result, nil
+}
+
+// joinTreePath joins repository path segments, ignoring an empty dir.
+func joinTreePath(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
+
+// displayTreePath names a repository directory for error messages.
+func displayTreePath(dir string) string {
+	if dir == "" {
+		return "/"
+	}
+	return dir
+}
+
 // treeBrowserModel is the TUI model for the file tree browser.
 type treeBrowserModel struct {
 	client   *api.Client
@@ -268,17 +416,19 @@ func runTreeBrowser(client *api.Client, store, repo, branch, path string) error
 
 func newFileReadCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "read <store/repo:branch:path>",
+		Use:     "read <store/repo:branch[#sha]:path>",
 		Short:   "Read file contents",
-		Example: "  scraps file read mystore/myrepo:main:README.md\n  scraps file read mystore/myrepo:main:src/index.ts",
+		Example: "  scraps file read mystore/myrepo:main:README.md\n  scraps file read mystore/myrepo:main:src/index.ts\n  scraps file read mystore/myrepo@feature/x:src/index.ts\n  scraps file read mystore/myrepo#a1b2c3d:README.md\n  scraps file read mystore/myrepo:main:logo.png -o json",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("file reference required\n\nUsage: scraps file read <store/repo:branch:path>\n\nExample: scraps file read mystore/myrepo:main:README.md")
 			}
 			return nil
 		},
+		ValidArgsFunction: completeBranchRef(":"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, repo, branch, path, err := parseStoreRepoBranchPath(args[0])
+			ref, commit := splitCommit(args[0])
+			store, repo, branch, path, err := parseStoreRepoBranchPath(ref)
 			if err != nil {
 				return err
 			}
@@ -292,36 +442,175 @@ func newFileReadCmd() *cobra.Command {
 				return err
 			}
 
-			content, err := client.GetFileContent(store, repo, branch, path)
+			content, err := client.GetFileContent(store, repo, pinnedRef(branch, commit), path)
 			if err != nil {
 				return err
 			}
 
+			if isStructuredOutput() {
+				fc, err := newFileContent(client, store, repo, branch, commit, path, content)
+				if err != nil {
+					return err
+				}
+				return outputStructured(fc)
+			}
+
 			// If interactive and content is large, use viewport
 			if isInteractive() && len(content) > 2000 {
 				return runFileViewer(string(content), path)
 			}
 
-			// Just output the content
-			fmt.Print(string(content))
+			// Just output the content, highlighted when writing to a terminal
+			fmt.Print(strings.Join(highlightLines(string(content), path, colorEnabled()), "\n"))
 			return nil
 		},
 	}
 	return cmd
 }
 
-// fileViewerModel is a scrollable file viewer.
+// --- File Download Command ---
+
+func newFileDownloadCmd() *cobra.Command {
+	var dest string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "download <store/repo:branch[#sha]:path>",
+		Short: "Save a file to the local disk",
+		Long: `Save a file to the local disk, byte for byte.
+
+The file is written to the basename of the remote path in the current
+directory unless --output is given. Existing files are left alone unless
+--force is passed.`,
+		Example: "  scraps file download mystore/myrepo:main:assets/logo.png\n  scraps file download mystore/myrepo:main:data.bin --output ./backup.bin",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("file reference required\n\nUsage: scraps file download <store/repo:branch:path>\n\nExample: scraps file download mystore/myrepo:main:assets/logo.png")
+			}
+			return nil
+		},
+		ValidArgsFunction: completeBranchRef(":"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, commit := splitCommit(args[0])
+			store, repo, branch, path, err := parseStoreRepoBranchPath(ref)
+			if err != nil {
+				return err
+			}
+			branch = pinnedRef(branch, commit)
+
+			if path == "" {
+				return fmt.Errorf("file path is required")
+			}
+
+			if dest == "" {
+				dest = filepath.Base(path)
+			}
+			if !force {
+				if _, err := os.Stat(dest); err == nil {
+					return fmt.Errorf("%s already exists; use --force to overwrite", dest)
+				}
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			content, err := client.GetFileContent(store, repo, branch, path)
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(dest, content, 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", dest, err)
+			}
+
+			success(fmt.Sprintf("Saved %s (%d bytes)", dest, len(content)))
+			return nil
+		},
+	}
+
+	// Shadows the global --output format flag; a download has no formatted output
+	cmd.Flags().StringVarP(&dest, "output", "o", "", "Local path to write to (default: basename of the remote path)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite an existing local file")
+
+	return cmd
+}
+
+// fileContent is the structured form of file read. Content is always
+// base64 so binary files survive the trip through JSON.
+type fileContent struct {
+	Path     string `json:"path"`
+	Branch   string `json:"branch"`
+	Commit   string `json:"commit,omitempty"`
+	Size     int    `json:"size"`
+	SHA      string `json:"sha"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// newFileContent wraps content with its metadata, looking the blob SHA up
+// in the parent directory's tree listing at commit, or at branch if no
+// commit was pinned.
+func newFileContent(client *api.Client, store, repo, branch, commit, filePath string, content []byte) (*fileContent, error) {
+	dir, name := "", filePath
+	if i := strings.LastIndex(filePath, "/"); i >= 0 {
+		dir, name = filePath[:i], filePath[i+1:]
+	}
+
+	entries, err := client.GetFileTree(store, repo, pinnedRef(branch, commit), dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sha string
+	for _, e := range entries {
+		if e.Name == name && e.Type == "blob" {
+			sha = e.SHA
+			break
+		}
+	}
+
+	return &fileContent{
+		Path:     filePath,
+		Branch:   branch,
+		Commit:   commit,
+		Size:     len(content),
+		SHA:      sha,
+		Encoding: "base64",
+		Content:  base64.StdEncoding.EncodeToString(content),
+	}, nil
+}
+
+// fileViewerModel is a scrollable file viewer with less-style search.
 type fileViewerModel struct {
 	viewport viewport.Model
 	filename string
-	content  string
+	plain    []string // content lines as-is, used for searching
+	lines    []string // content lines, highlighted if possible
 	ready    bool
+
+	searching bool            // the search input is open
+	search    textinput.Model // search input
+	query     string          // last submitted search
+	matches   []int           // line indexes containing query
+	current   int             // index into matches of the focused match
 }
 
+// matchStyle marks the focused search match.
+var matchStyle = lipgloss.NewStyle().Reverse(true)
+
 func newFileViewerModel(content, filename string) fileViewerModel {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.CharLimit = 256
+	ti.PromptStyle = tui.PromptStyle
+
 	return fileViewerModel{
-		content:  content,
+		plain:    strings.Split(content, "\n"),
+		lines:    highlightLines(content, filename, colorEnabled()),
 		filename: filename,
+		search:   ti,
 	}
 }
 
@@ -329,6 +618,91 @@ func (m fileViewerModel) Init() tea.Cmd {
 	return nil
 }
 
+// findMatches returns the indexes of lines containing query, ignoring case.
+func findMatches(lines []string, query string) []int {
+	if query == "" {
+		return nil
+	}
+	var matches []int
+	for i, line := range lines {
+		if start, _ := indexFold(line, query); start >= 0 {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// indexFold returns the byte range of the first case-insensitive occurrence
+// of query in s, or -1, -1 if there is none. It compares rune by rune with
+// simple case folding rather than lowercasing, since lowercasing can change
+// a line's byte length and so its offsets.
+func indexFold(s, query string) (int, int) {
+	n := utf8.RuneCountInString(query)
+	for start := range s {
+		end, runes := start, 0
+		for runes < n && end < len(s) {
+			_, size := utf8.DecodeRuneInString(s[end:])
+			end += size
+			runes++
+		}
+		if runes < n {
+			break
+		}
+		if strings.EqualFold(s[start:end], query) {
+			return start, end
+		}
+	}
+	return -1, -1
+}
+
+// markMatches wraps every case-insensitive occurrence of query in line with
+// matchStyle.
+func markMatches(line, query string) string {
+	if query == "" {
+		return line
+	}
+	var b strings.Builder
+	for {
+		start, end := indexFold(line, query)
+		if start < 0 {
+			b.WriteString(line)
+			return b.String()
+		}
+		b.WriteString(line[:start])
+		b.WriteString(matchStyle.Render(line[start:end]))
+		line = line[end:]
+	}
+}
+
+// renderContent sets the viewport content: line numbers plus each line,
+// with the focused match shown plain and marked.
+func (m *fileViewerModel) renderContent() {
+	focused := -1
+	if len(m.matches) > 0 {
+		focused = m.matches[m.current]
+	}
+
+	numberedLines := make([]string, len(m.lines))
+	for i, line := range m.lines {
+		lineNum := lipgloss.NewStyle().Foreground(tui.ColorMuted).Render(fmt.Sprintf("%4d ", i+1))
+		if i == focused {
+			line = markMatches(m.plain[i], m.query)
+		}
+		numberedLines[i] = lineNum + line
+	}
+	m.viewport.SetContent(strings.Join(numberedLines, "\n"))
+}
+
+// jumpTo focuses match n (wrapping around) and scrolls it into view.
+func (m *fileViewerModel) jumpTo(n int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.current = (n%len(m.matches) + len(m.matches)) % len(m.matches)
+	m.renderContent()
+	m.viewport.SetYOffset(m.matches[m.current])
+}
+
 func (m fileViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -345,20 +719,49 @@ func (m fileViewerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.Width = msg.Width
 			m.viewport.Height = msg.Height - headerHeight - footerHeight
 		}
+		m.renderContent()
 
-		// Add line numbers
-		lines := strings.Split(m.content, "\n")
-		var numberedLines []string
-		for i, line := range lines {
-			lineNum := lipgloss.NewStyle().Foreground(tui.ColorMuted).Render(fmt.Sprintf("%4d ", i+1))
-			numberedLines = append(numberedLines, lineNum+line)
+	case tea.KeyMsg:
+		if m.searching {
+			switch msg.String() {
+			case "enter":
+				m.searching = false
+				m.search.Blur()
+				m.query = m.search.Value()
+				m.matches = findMatches(m.plain, m.query)
+				// Start from the first match at or below the current position
+				first := 0
+				for i, line := range m.matches {
+					if line >= m.viewport.YOffset {
+						first = i
+						break
+					}
+				}
+				m.current = 0
+				m.renderContent()
+				m.jumpTo(first)
+			case "esc", "ctrl+c":
+				m.searching = false
+				m.search.Blur()
+			default:
+				m.search, cmd = m.search.Update(msg)
+			}
+			return m, cmd
 		}
-		m.viewport.SetContent(strings.Join(numberedLines, "\n"))
 
-	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
 			return m, tea.Quit
+		case "/":
+			m.searching = true
+			m.search.SetValue("")
+			return m, m.search.Focus()
+		case "n":
+			m.jumpTo(m.current + 1)
+			return m, nil
+		case "N":
+			m.jumpTo(m.current - 1)
+			return m, nil
 		}
 	}
 
@@ -372,9 +775,21 @@ func (m fileViewerModel) View() string {
 	}
 
 	header := tui.TitleStyle.Render(m.filename) + "\n" + strings.Repeat("─", m.viewport.Width) + "\n"
-	footer := "\n" + tui.HelpStyle.Render(fmt.Sprintf("↑↓ scroll  q quit  %d%%", int(m.viewport.ScrollPercent()*100)))
 
-	return header + m.viewport.View() + footer
+	var status string
+	switch {
+	case m.searching:
+		status = m.search.View()
+	case m.query != "" && len(m.matches) == 0:
+		status = tui.ErrorStyle.Render(fmt.Sprintf("Pattern not found: %s", m.query))
+	case m.query != "":
+		status = tui.HelpStyle.Render(fmt.Sprintf("match %d/%d  n next  N prev  / search  q quit  %d%%",
+			m.current+1, len(m.matches), int(m.viewport.ScrollPercent()*100)))
+	default:
+		status = tui.HelpStyle.Render(fmt.Sprintf("↑↓ scroll  / search  q quit  %d%%", int(m.viewport.ScrollPercent()*100)))
+	}
+
+	return header + m.viewport.View() + "\n" + status
 }
 
 func runFileViewer(content, filename string) error {
@@ -383,3 +798,149 @@ func runFileViewer(content, filename string) error {
 	_, err := p.Run()
 	return err
 }
+
+// --- File Write Command ---
+
+func newFileWriteCmd() *cobra.Command {
+	var message, file string
+
+	cmd := &cobra.Command{
+		Use:   "write <store/repo:branch:path>",
+		Short: "Write file contents and commit",
+		Example: `  scraps file write mystore/myrepo:main:README.md --file ./README.md -m "Update readme"
+  echo "hello" | scraps file write mystore/myrepo:main:hello.txt -m "Add hello"`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("file reference required\n\nUsage: scraps file write <store/repo:branch:path>\n\nExample: scraps file write mystore/myrepo:main:README.md --file ./README.md")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, repo, branch, path, err := parseStoreRepoBranchPath(args[0])
+			if err != nil {
+				return err
+			}
+
+			if path == "" {
+				return fmt.Errorf("file path is required")
+			}
+
+			var content []byte
+			switch {
+			case file != "":
+				content, err = os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", file, err)
+				}
+			case isInputInteractive():
+				values, err := components.RunWizard("Write File", []components.WizardStep{
+					components.NewTextareaStep(path, "Enter the file content:", "").
+						WithCharLimit(100000).
+						WithSize(80, 15),
+				})
+				if err != nil {
+					return err
+				}
+				if values == nil {
+					info("Write cancelled")
+					return nil
+				}
+				content = []byte(values[0].(string))
+			default:
+				content, err = io.ReadAll(os.Stdin)
+				if err != nil {
+					return fmt.Errorf("failed to read stdin: %w", err)
+				}
+			}
+
+			if message == "" {
+				message = "Update " + path
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			if err := client.PutFileContent(store, repo, branch, path, content, message); err != nil {
+				var apiErr *api.APIError
+				if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+					return fmt.Errorf("branch '%s' not found in %s: %w", branch, formatStoreRepo(store, repo), err)
+				}
+				return err
+			}
+
+			success(fmt.Sprintf("Wrote %s (%d bytes) to %s", path, len(content), formatStoreRepoBranch(store, repo, branch)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Commit message")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Read content from a local file instead of stdin")
+
+	return cmd
+}
+
+// --- File Delete Command ---
+
+func newFileDeleteCmd() *cobra.Command {
+	var message string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "delete <store/repo:branch:path>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a file and commit",
+		Example: `  scraps file delete mystore/myrepo:main:tmp/scratch.txt -m "Remove scratch file"`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("file reference required\n\nUsage: scraps file delete <store/repo:branch:path> --message <msg>\n\nExample: scraps file delete mystore/myrepo:main:tmp/scratch.txt -m \"Remove scratch file\"")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, repo, branch, path, err := parseStoreRepoBranchPath(args[0])
+			if err != nil {
+				return err
+			}
+
+			if path == "" {
+				return fmt.Errorf("file path is required")
+			}
+
+			// Confirm deletion
+			if !force && isInteractive() {
+				confirmed, err := components.RunConfirm(
+					"Delete File",
+					fmt.Sprintf("Are you sure you want to delete '%s'?\nBranch: %s\nRepository: %s", path, branch, formatStoreRepo(store, repo)),
+					true,
+				)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					info("Deletion cancelled")
+					return nil
+				}
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteFile(store, repo, branch, path, message); err != nil {
+				return err
+			}
+
+			success(fmt.Sprintf("Deleted %s from %s", path, formatStoreRepoBranch(store, repo, branch)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Commit message")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+	cmd.MarkFlagRequired("message")
+
+	return cmd
+}
@@ -0,0 +1,372 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestFindMatches(t *testing.T) {
+	lines := []string{"package main", "", "func Main() {", "\tmain()", "}"}
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"main", []int{0, 2, 3}},
+		{"MAIN", []int{0, 2, 3}},
+		{"func", []int{2}},
+		{"missing", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := findMatches(lines, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("findMatches(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMarkMatches(t *testing.T) {
+	mark := func(s string) string { return matchStyle.Render(s) }
+
+	tests := []struct {
+		name  string
+		line  string
+		query string
+		want  string
+	}{
+		{name: "ascii", line: "Main calls main", query: "main", want: mark("Main") + " calls " + mark("main")},
+		// Ⱥ lowercases to a longer encoding, which used to shift offsets
+		{name: "longer lowercase", line: "Ⱥx", query: "x", want: "Ⱥ" + mark("x")},
+		{name: "match longer lowercase", line: "aȺb", query: "ⱥ", want: "a" + mark("Ⱥ") + "b"},
+		// The Kelvin sign lowercases to a single-byte k
+		{name: "shorter lowercase", line: "\u212Aelvin ok", query: "ok", want: "\u212Aelvin " + mark("ok")},
+		{name: "kelvin folds to k", line: "\u212A2", query: "k", want: mark("\u212A") + "2"},
+		{name: "no match", line: "naïve", query: "x", want: "naïve"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markMatches(tt.line, tt.query); got != tt.want {
+				t.Errorf("markMatches(%q, %q) = %q, want %q", tt.line, tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if got := findMatches([]string{"ascii", "Ⱥx", "\u212Aelvin"}, "K"); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("findMatches(K) = %v, want [2]", got)
+	}
+}
+
+func TestFileViewerSearch(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	typeKeys := func(m tea.Model, s string) tea.Model {
+		for _, r := range s {
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		return m
+	}
+
+	content := "alpha\nbeta\nalpha two\ngamma\nALPHA three"
+	var m tea.Model = newFileViewerModel(content, "notes.txt")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 40, Height: 7})
+
+	m = typeKeys(m, "/alpha")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	fm := m.(fileViewerModel)
+	if fm.searching {
+		t.Fatal("search input still open after enter")
+	}
+	if want := []int{0, 2, 4}; !reflect.DeepEqual(fm.matches, want) {
+		t.Fatalf("matches = %v, want %v", fm.matches, want)
+	}
+	if fm.current != 0 {
+		t.Errorf("current = %d, want 0", fm.current)
+	}
+
+	steps := []struct {
+		key     string
+		current int
+	}{
+		{"n", 1},
+		{"n", 2},
+		{"n", 0}, // wraps to the first match
+		{"N", 2}, // wraps to the last match
+		{"N", 1},
+	}
+	for _, step := range steps {
+		m = typeKeys(m, step.key)
+		fm = m.(fileViewerModel)
+		if fm.current != step.current {
+			t.Fatalf("after %s: current = %d, want %d", step.key, fm.current, step.current)
+		}
+		if want := fm.matches[fm.current]; fm.viewport.YOffset != want && !fm.viewport.AtBottom() {
+			t.Errorf("after %s: YOffset = %d, want %d", step.key, fm.viewport.YOffset, want)
+		}
+	}
+
+	// esc closes the search input without quitting
+	m = typeKeys(m, "/x")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.(fileViewerModel).searching || cmd != nil {
+		t.Error("esc in search input should close it without quitting")
+	}
+
+	// With the input closed, q still quits
+	_, cmd = typeKeys(m, "").Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if cmd == nil {
+		t.Fatal("q should quit the viewer")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("q should quit the viewer")
+	}
+}
+
+func TestNewFileContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		treePath string
+		wantSHA  string
+	}{
+		{name: "nested file", path: "img/logo.png", treePath: "/api/v1/stores/s/repos/r/tree/main/img", wantSHA: "b10b"},
+		{name: "root file", path: "logo.png", treePath: "/api/v1/stores/s/repos/r/tree/main", wantSHA: "b10b"},
+		{name: "not in tree", path: "img/gone.png", treePath: "/api/v1/stores/s/repos/r/tree/main/img", wantSHA: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.treePath {
+					t.Errorf("unexpected request %s", r.URL.Path)
+				}
+				json.NewEncoder(w).Encode([]model.FileTreeEntry{
+					{Type: "tree", Name: "logo.png", SHA: "d1r"}, // same name, but a directory
+					{Type: "blob", Name: "logo.png", SHA: "b10b"},
+				})
+			}))
+			defer server.Close()
+
+			t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+			client := api.NewClient(server.URL, "test-key")
+			content := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+
+			fc, err := newFileContent(client, "s", "r", "main", "", tt.path, content)
+			if err != nil {
+				t.Fatalf("newFileContent: %v", err)
+			}
+			if fc.Path != tt.path || fc.Branch != "main" || fc.Size != len(content) || fc.Encoding != "base64" {
+				t.Errorf("metadata = %+v", fc)
+			}
+			if fc.SHA != tt.wantSHA {
+				t.Errorf("SHA = %q, want %q", fc.SHA, tt.wantSHA)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(fc.Content)
+			if err != nil || !reflect.DeepEqual(decoded, content) {
+				t.Errorf("Content decodes to %v (%v), want %v", decoded, err, content)
+			}
+		})
+	}
+}
+
+func TestFileDownload(t *testing.T) {
+	content := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x00, 0xff}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/stores/s/repos/r/files/main/img/logo.png" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Chdir(t.TempDir())
+
+	download := func(args ...string) error {
+		cmd := newFileDownloadCmd()
+		cmd.SetArgs(append([]string{"s/r:main:img/logo.png"}, args...))
+		var err error
+		captureStdout(t, func() { err = cmd.Execute() })
+		return err
+	}
+
+	// Defaults to the remote basename
+	if err := download(); err != nil {
+		t.Fatalf("download error = %v", err)
+	}
+	if got, err := os.ReadFile("logo.png"); err != nil || !reflect.DeepEqual(got, content) {
+		t.Errorf("logo.png = %v (%v), want %v", got, err, content)
+	}
+
+	// Refuses to overwrite without --force
+	os.WriteFile("logo.png", []byte("keep"), 0o644)
+	if err := download(); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("download over existing file error = %v, want --force hint", err)
+	}
+	if got, _ := os.ReadFile("logo.png"); string(got) != "keep" {
+		t.Errorf("existing file was overwritten without --force")
+	}
+	if err := download("--force"); err != nil {
+		t.Fatalf("download --force error = %v", err)
+	}
+	if got, _ := os.ReadFile("logo.png"); !reflect.DeepEqual(got, content) {
+		t.Errorf("--force did not overwrite: %v", got)
+	}
+
+	// --output picks the local path
+	dest := filepath.Join(t.TempDir(), "copy.bin")
+	if err := download("--output", dest); err != nil {
+		t.Fatalf("download --output error = %v", err)
+	}
+	if got, err := os.ReadFile(dest); err != nil || !reflect.DeepEqual(got, content) {
+		t.Errorf("%s = %v (%v), want %v", dest, got, err, content)
+	}
+}
+
+func TestWalkFileTree(t *testing.T) {
+	const prefix = "/api/v1/stores/s/repos/r/tree/main"
+	trees := map[string][]model.FileTreeEntry{
+		"":         {{Type: "tree", Name: "src"}, {Type: "blob", Name: "README.md", SHA: "r1"}},
+		"/src":     {{Type: "blob", Name: "main.go", SHA: "m1"}, {Type: "tree", Name: "lib"}},
+		"/src/lib": {{Type: "blob", Name: "util.go", SHA: "u1"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entries, ok := trees[strings.TrimPrefix(r.URL.Path, prefix)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(entries)
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	client := api.NewClient(server.URL, "test-key")
+
+	tests := []struct {
+		name  string
+		root  string
+		depth int
+		want  []string
+	}{
+		{name: "whole repo", want: []string{"tree src", "blob src/main.go", "tree src/lib", "blob src/lib/util.go", "blob README.md"}},
+		{name: "depth 1", depth: 1, want: []string{"tree src", "blob README.md"}},
+		{name: "depth 2", depth: 2, want: []string{"tree src", "blob src/main.go", "tree src/lib", "blob README.md"}},
+		{name: "subdirectory", root: "src/", want: []string{"blob src/main.go", "tree src/lib", "blob src/lib/util.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := walkFileTree(client, "s", "r", "main", tt.root, tt.depth)
+			if err != nil {
+				t.Fatalf("walkFileTree error = %v", err)
+			}
+			got := make([]string, len(entries))
+			for i, e := range entries {
+				got[i] = e.Type + " " + e.Path
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("walkFileTree = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	delete(trees, "/src/lib")
+	if _, err := walkFileTree(client, "s", "r", "main", "", 0); err == nil || !strings.Contains(err.Error(), "src/lib") {
+		t.Errorf("walkFileTree with failing subdirectory error = %v, want it to name src/lib", err)
+	}
+}
+
+func TestFileTreeShortSHA(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]model.FileTreeEntry{
+			{Type: "blob", Name: "a.go", SHA: "abc"},
+			{Type: "blob", Name: "b.go", SHA: "0123456789abcdef"},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "csv")
+
+	// SHAs shorter than the display width are shown whole
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"s/r"}, want: "TYPE,NAME,SHA\nblob,a.go,abc\nblob,b.go,0123456\n"},
+		{args: []string{"s/r", "--recursive"}, want: "TYPE,PATH,SHA\nblob,a.go,abc\nblob,b.go,0123456\n"},
+	}
+
+	for _, tt := range tests {
+		cmd := newFileTreeCmd()
+		cmd.SetArgs(tt.args)
+		var err error
+		out := captureStdout(t, func() { err = cmd.Execute() })
+		if err != nil {
+			t.Fatalf("file tree %v error = %v", tt.args, err)
+		}
+		if out != tt.want {
+			t.Errorf("file tree %v output = %q, want %q", tt.args, out, tt.want)
+		}
+	}
+}
+
+func TestFileReadPinnedCommit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/stores/s/repos/r/files/abc123/src/a.go":
+			w.Write([]byte("package a\n"))
+		case "/api/v1/stores/s/repos/r/tree/abc123/src":
+			json.NewEncoder(w).Encode([]model.FileTreeEntry{{Type: "blob", Name: "a.go", SHA: "blobsha"}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+	for _, ref := range []string{"s/r:main#abc123:src/a.go", "s/r#abc123:src/a.go"} {
+		t.Run(ref, func(t *testing.T) {
+			cmd := newFileReadCmd()
+			cmd.SetArgs([]string{ref})
+			var err error
+			out := captureStdout(t, func() { err = cmd.Execute() })
+			if err != nil {
+				t.Fatalf("file read %s error = %v", ref, err)
+			}
+
+			var fc fileContent
+			if err := json.Unmarshal([]byte(out), &fc); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, out)
+			}
+			if fc.Commit != "abc123" || fc.SHA != "blobsha" || fc.Path != "src/a.go" {
+				t.Errorf("file read %s = %+v", ref, fc)
+			}
+		})
+	}
+}
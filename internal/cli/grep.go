@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+)
+
+// grepConcurrency bounds the number of files file grep fetches at once.
+const grepConcurrency = 8
+
+// defaultGrepMaxFileSize is the default --max-file-size, in bytes.
+const defaultGrepMaxFileSize = 1 << 20
+
+// grepMatch is a single matching line.
+type grepMatch struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+func newFileGrepCmd() *cobra.Command {
+	var filesWithMatches bool
+	var maxFileSize int64
+
+	cmd := &cobra.Command{
+		Use:   "grep <store/repo[:branch][#sha]> <regex> [pathglob]",
+		Short: "Search file contents in a repository",
+		Long: `Search file contents in a repository without cloning it.
+
+Every file in the tree is fetched and matched line by line against a Go
+regular expression; prefix it with (?i) to ignore case. An optional glob
+limits the files searched: a glob containing "/" is matched against the
+whole path, otherwise against the file name. Binary files and files over
+--max-file-size are skipped.
+
+Listing the tree takes one request per directory and each file is fetched
+separately, so narrow the search with a glob on large repositories.`,
+		Example: `  scraps file grep mystore/myrepo TODO
+  scraps file grep mystore/myrepo:dev 'func \w+Handler' '*.go'
+  scraps file grep mystore/myrepo -l '(?i)deprecated' 'docs/*.md'`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return fmt.Errorf("repository and pattern required\n\nUsage: scraps file grep <store/repo[:branch]> <regex> [pathglob]\n\nExample: scraps file grep mystore/myrepo TODO '*.go'")
+			}
+			if len(args) > 3 {
+				return fmt.Errorf("too many arguments\n\nUsage: scraps file grep <store/repo[:branch]> <regex> [pathglob]")
+			}
+			return nil
+		},
+		ValidArgsFunction: completeBranchRef(""),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, commit := splitCommit(args[0])
+			store, repo, branch, err := parseStoreRepoBranch(ref)
+			if err != nil {
+				return err
+			}
+			if branch == "" {
+				branch = "main"
+			}
+			branch = pinnedRef(branch, commit)
+
+			re, err := regexp.Compile(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid pattern: %w", err)
+			}
+
+			glob := ""
+			if len(args) > 2 {
+				glob = args[2]
+				if _, err := path.Match(glob, ""); err != nil {
+					return fmt.Errorf("invalid glob %q: %w", glob, err)
+				}
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			matches, err := grepRepo(client, store, repo, branch, re, glob, maxFileSize)
+			if err != nil {
+				return err
+			}
+
+			if filesWithMatches {
+				paths := []string{}
+				for _, m := range matches {
+					if len(paths) == 0 || paths[len(paths)-1] != m.Path {
+						paths = append(paths, m.Path)
+					}
+				}
+				if isStructuredOutput() {
+					return outputStructured(paths)
+				}
+				for _, p := range paths {
+					fmt.Println(p)
+				}
+				return nil
+			}
+
+			if isStructuredOutput() {
+				if matches == nil {
+					matches = []grepMatch{}
+				}
+				return outputStructured(matches)
+			}
+			for _, m := range matches {
+				fmt.Printf("%s:%d:%s\n", m.Path, m.Line, m.Text)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&filesWithMatches, "files-with-matches", "l", false, "Only print the paths of files that match")
+	cmd.Flags().Int64Var(&maxFileSize, "max-file-size", defaultGrepMaxFileSize, "Skip files larger than this many bytes")
+
+	return cmd
+}
+
+// grepRepo searches every file in the repository whose path matches glob
+// (all files if glob is empty), returning matches in tree order.
+func grepRepo(client *api.Client, store, repo, branch string, re *regexp.Regexp, glob string, maxFileSize int64) ([]grepMatch, error) {
+	entries, err := walkFileTree(client, store, repo, branch, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.Type == "blob" && matchPathGlob(glob, e.Path) {
+			files = append(files, e.Path)
+		}
+	}
+
+	// Each worker writes only its own slot, so no locking is needed
+	perFile := make([][]grepMatch, len(files))
+	errs := make([]error, len(files))
+	sem := make(chan struct{}, grepConcurrency)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			content, err := client.GetFileContent(store, repo, branch, file)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to read %s: %w", file, err)
+				return
+			}
+			perFile[i] = grepContent(file, content, re, maxFileSize)
+		}(i, file)
+	}
+	wg.Wait()
+
+	var matches []grepMatch
+	for i := range files {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		matches = append(matches, perFile[i]...)
+	}
+	return matches, nil
+}
+
+// grepContent returns the lines of content matching re. Binary content and
+// content over maxFileSize bytes (when positive) are not searched.
+func grepContent(file string, content []byte, re *regexp.Regexp, maxFileSize int64) []grepMatch {
+	if maxFileSize > 0 && int64(len(content)) > maxFileSize {
+		return nil
+	}
+	if bytes.IndexByte(content, 0) >= 0 {
+		return nil
+	}
+
+	var matches []grepMatch
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if re.MatchString(line) {
+			matches = append(matches, grepMatch{Path: file, Line: i + 1, Text: line})
+		}
+	}
+	return matches
+}
+
+// matchPathGlob reports whether file matches glob. Globs without a "/" are
+// matched against the file name only; an empty glob matches everything.
+func matchPathGlob(glob, file string) bool {
+	if glob == "" {
+		return true
+	}
+	target := file
+	if !strings.Contains(glob, "/") {
+		target = path.Base(file)
+	}
+	ok, _ := path.Match(glob, target)
+	return ok
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		glob string
+		path string
+		want bool
+	}{
+		{"", "src/main.go", true},
+		{"*.go", "main.go", true},
+		{"*.go", "src/lib/util.go", true},
+		{"*.go", "README.md", false},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "src/lib/util.go", false},
+		{"docs/*", "src/docs/a.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchPathGlob(tt.glob, tt.path); got != tt.want {
+			t.Errorf("matchPathGlob(%q, %q) = %v, want %v", tt.glob, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestGrepContent(t *testing.T) {
+	re := regexp.MustCompile(`TODO`)
+
+	tests := []struct {
+		name    string
+		content string
+		maxSize int64
+		want    []int
+	}{
+		{name: "matches", content: "a\n// TODO one\nb\r\nTODO two\r\n", want: []int{2, 4}},
+		{name: "no matches", content: "nothing here\n", want: nil},
+		{name: "binary", content: "TODO\x00\x01", want: nil},
+		{name: "too large", content: "TODO\n", maxSize: 3, want: nil},
+		{name: "no limit", content: "TODO\n", maxSize: 0, want: []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := grepContent("f.txt", []byte(tt.content), re, tt.maxSize)
+			var lines []int
+			for _, m := range matches {
+				lines = append(lines, m.Line)
+				if strings.HasSuffix(m.Text, "\r") {
+					t.Errorf("line %d text %q keeps the carriage return", m.Line, m.Text)
+				}
+			}
+			if !reflect.DeepEqual(lines, tt.want) {
+				t.Errorf("matched lines = %v, want %v", lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrepRepo(t *testing.T) {
+	const prefix = "/api/v1/stores/s/repos/r"
+	trees := map[string][]model.FileTreeEntry{
+		"/tree/main":     {{Type: "tree", Name: "src"}, {Type: "blob", Name: "README.md"}},
+		"/tree/main/src": {{Type: "blob", Name: "a.go"}, {Type: "blob", Name: "b.go"}},
+	}
+	files := map[string]string{
+		"/files/main/README.md": "TODO: write docs\n",
+		"/files/main/src/a.go":  "package src\n// TODO: tests\n",
+		"/files/main/src/b.go":  "package src\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimPrefix(r.URL.Path, prefix)
+		if entries, ok := trees[p]; ok {
+			json.NewEncoder(w).Encode(entries)
+			return
+		}
+		if content, ok := files[p]; ok {
+			w.Write([]byte(content))
+			return
+		}
+		t.Errorf("unexpected request %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	client := api.NewClient(server.URL, "test-key")
+	re := regexp.MustCompile(`TODO`)
+
+	matches, err := grepRepo(client, "s", "r", "main", re, "", defaultGrepMaxFileSize)
+	if err != nil {
+		t.Fatalf("grepRepo error = %v", err)
+	}
+	want := []grepMatch{
+		{Path: "src/a.go", Line: 2, Text: "// TODO: tests"},
+		{Path: "README.md", Line: 1, Text: "TODO: write docs"},
+	}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("grepRepo = %+v, want %+v", matches, want)
+	}
+
+	matches, err = grepRepo(client, "s", "r", "main", re, "*.go", defaultGrepMaxFileSize)
+	if err != nil {
+		t.Fatalf("grepRepo with glob error = %v", err)
+	}
+	if len(matches) != 1 || matches[0].Path != "src/a.go" {
+		t.Errorf("grepRepo with *.go = %+v, want only src/a.go", matches)
+	}
+}
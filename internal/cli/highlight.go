@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// maxHighlightBytes is the largest file that gets syntax highlighted;
+// bigger files are shown plain to keep the viewer responsive.
+const maxHighlightBytes = 256 * 1024
+
+// highlightStyle is the chroma style used for file content.
+const highlightStyle = "monokai"
+
+// highlightLines splits content into lines, syntax highlighting them for
+// terminal output when color is true and the language can be detected from
+// filename. Each returned line carries its own escape codes, so a gutter can
+// be prepended without disturbing colors, and the line count always matches
+// strings.Split(content, "\n").
+func highlightLines(content, filename string, color bool) []string {
+	plain := strings.Split(content, "\n")
+	if !color || len(content) > maxHighlightBytes {
+		return plain
+	}
+
+	lexer := lexers.Match(filename)
+	if lexer == nil {
+		return plain
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, content)
+	if err != nil {
+		return plain
+	}
+
+	formatter := formatters.Get("terminal256")
+	style := styles.Get(highlightStyle)
+
+	var lines []string
+	for _, tokens := range chroma.SplitTokensIntoLines(iterator.Tokens()) {
+		var buf bytes.Buffer
+		if err := formatter.Format(&buf, style, chroma.Literator(tokens...)); err != nil {
+			return plain
+		}
+		lines = append(lines, strings.TrimRight(buf.String(), "\n"))
+	}
+
+	// A trailing newline produces an empty final line that chroma omits
+	for len(lines) < len(plain) {
+		lines = append(lines, "")
+	}
+	if len(lines) != len(plain) {
+		return plain
+	}
+	return lines
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightLines(t *testing.T) {
+	goSrc := "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n"
+
+	tests := []struct {
+		name     string
+		content  string
+		filename string
+		color    bool
+		wantANSI bool
+	}{
+		{name: "go with color", content: goSrc, filename: "main.go", color: true, wantANSI: true},
+		{name: "no trailing newline", content: strings.TrimSuffix(goSrc, "\n"), filename: "main.go", color: true, wantANSI: true},
+		{name: "color disabled", content: goSrc, filename: "main.go", color: false, wantANSI: false},
+		{name: "unknown extension", content: "just some text\n", filename: "notes.unknownext", color: true, wantANSI: false},
+		{name: "too large", content: strings.Repeat("x = 1\n", maxHighlightBytes/6+1), filename: "big.py", color: true, wantANSI: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := highlightLines(tt.content, tt.filename, tt.color)
+			plain := strings.Split(tt.content, "\n")
+			if len(got) != len(plain) {
+				t.Fatalf("highlightLines() returned %d lines, want %d", len(got), len(plain))
+			}
+			joined := strings.Join(got, "\n")
+			if hasANSI := strings.Contains(joined, "\x1b["); hasANSI != tt.wantANSI {
+				t.Errorf("highlightLines() escape codes = %v, want %v", hasANSI, tt.wantANSI)
+			}
+			for i, line := range got {
+				if strings.Contains(line, "\n") {
+					t.Errorf("line %d contains a newline: %q", i, line)
+				}
+			}
+		})
+	}
+}
@@ -38,7 +38,7 @@ func newKeyResetRequestCmd() *cobra.Command {
 			email := args[0]
 
 			if host == "" {
-				host = config.GetHost()
+				host = api.DefaultHost()
 			}
 
 			client := api.NewClient(host, "")
@@ -74,7 +74,7 @@ func newKeyResetConfirmCmd() *cobra.Command {
 			token := args[0]
 
 			if host == "" {
-				host = config.GetHost()
+				host = api.DefaultHost()
 			}
 
 			client := api.NewClient(host, "")
@@ -96,7 +96,7 @@ func newKeyResetConfirmCmd() *cobra.Command {
 				}
 			}
 
-			fmt.Printf("\nYour new API key: %s\n", resp.APIKey)
+			fmt.Printf("\nYour new API key: %s\n", maskSecret(resp.APIKey))
 			fmt.Println("Save this key - it won't be shown again!")
 
 			return nil
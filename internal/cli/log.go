@@ -2,28 +2,45 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/morrisclay/scraps-cli/internal/api"
-	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/model"
 )
 
 func newLogCmd() *cobra.Command {
 	var limit int
+	var oneline bool
+	var format string
+	var sinceFlag, untilFlag string
 
 	cmd := &cobra.Command{
-		Use:     "log <store/repo[:branch]>",
-		Short:   "Show commit history",
-		Example: "  scraps log mystore/myrepo\n  scraps log mystore/myrepo:main -n 20",
+		Use:   "log <store/repo[:branch][#sha]>",
+		Short: "Show commit history",
+		Long: `Show commit history for a branch.
+
+--since and --until accept RFC3339 timestamps, dates (YYYY-MM-DD) or
+relative durations such as 24h or 7d. When either filter is set, commits
+whose date cannot be parsed are excluded from the output.`,
+		Example: `  scraps log mystore/myrepo
+  scraps log mystore/myrepo:main -n 20
+  scraps log mystore/myrepo --oneline
+  scraps log mystore/myrepo --format "%h %an %s"
+  scraps log mystore/myrepo --since 24h
+  scraps log mystore/myrepo --since 2024-03-01T00:00:00Z --until 7d`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("repository reference required\n\nUsage: scraps log <store/repo[:branch]>\n\nExample: scraps log mystore/myrepo")
 			}
 			return nil
 		},
+		ValidArgsFunction: completeBranchRef(""),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			store, repo, branch, err := parseStoreRepoBranch(args[0])
+			ref, commit := splitCommit(args[0])
+			store, repo, branch, err := parseStoreRepoBranch(ref)
 			if err != nil {
 				return err
 			}
@@ -31,44 +48,60 @@ func newLogCmd() *cobra.Command {
 			if branch == "" {
 				branch = "main"
 			}
+			// Log from the pinned commit back
+			branch = pinnedRef(branch, commit)
+
+			// Validate the format before making any requests
+			if format != "" {
+				if err := validateLogFormat(format); err != nil {
+					return err
+				}
+			}
+
+			now := time.Now()
+			since, err := parseTimeBound(sinceFlag, now)
+			if err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+			until, err := parseTimeBound(untilFlag, now)
+			if err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
 
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
 				return err
 			}
 
-			commits, err := client.GetLog(store, repo, branch, limit)
+			commits, err := client.GetLogFiltered(store, repo, branch, limit, since, until)
 			if err != nil {
 				return err
 			}
 
+			// The server may ignore since/until, so filter locally too
+			commits = filterCommitsByTime(commits, since, until)
+
 			if len(commits) == 0 {
 				info("No commits found")
 				return nil
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(commits)
-			} else {
-				for _, c := range commits {
-					sha := c.SHA
-					if sha == "" {
-						sha = c.Commit
-					}
-					if len(sha) > 7 {
-						sha = sha[:7]
-					}
+			if isStructuredOutput() {
+				return outputStructured(commits)
+			}
 
-					author := ""
-					if c.Author.Name != "" {
-						author = c.Author.Name
-					} else if c.Author.Raw != "" {
-						author = c.Author.Raw
-					}
+			for _, c := range commits {
+				switch {
+				case format != "":
+					fmt.Println(formatLogCommit(format, c))
+				case oneline:
+					fmt.Printf("%s %s\n", colorize(colorYellow, shortSHA(commitSHA(c))), commitSubject(c))
+				default:
+					author := commitAuthor(c)
 
 					date := ""
 					if c.Date != "" {
-						date = formatDateTime(c.Date)
+						date = formatTableDateTime(c.Date)
 					}
 
 					msg := c.Message
@@ -76,7 +109,7 @@ func newLogCmd() *cobra.Command {
 						msg = msg[:57] + "..."
 					}
 
-					fmt.Printf("\033[33m%s\033[0m %s\n", sha, msg)
+					fmt.Printf("%s %s\n", colorize(colorYellow, shortSHA(commitSHA(c))), msg)
 					if author != "" || date != "" {
 						fmt.Printf("         %s %s\n", author, date)
 					}
@@ -87,5 +120,173 @@ func newLogCmd() *cobra.Command {
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "n", 10, "Number of commits to show")
+	cmd.Flags().BoolVar(&oneline, "oneline", false, "Show one commit per line")
+	cmd.Flags().StringVar(&sinceFlag, "since", "", "Only show commits after this time (RFC3339 or relative, e.g. 24h, 7d)")
+	cmd.Flags().StringVar(&untilFlag, "until", "", "Only show commits before this time (RFC3339 or relative, e.g. 24h, 7d)")
+	cmd.Flags().StringVar(&format, "format", "", "Format commits with placeholders: %H sha, %h short sha, %s subject, %an author, %ad date")
 	return cmd
 }
+
+// parseTimeBound parses a --since/--until value. It accepts RFC3339
+// timestamps, plain dates (YYYY-MM-DD) or a relative duration such as
+// "24h" or "7d", which is interpreted as that long before now.
+// An empty value returns the zero time.
+func parseTimeBound(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	d, err := parseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC3339 time or duration (e.g. 24h, 7d)", s)
+	}
+	return now.Add(-d), nil
+}
+
+// commitTime returns the time a commit was made, from its Date or
+// Timestamp field. The second return value is false if neither parses.
+func commitTime(c model.Commit) (time.Time, bool) {
+	if c.Date != "" {
+		if t, err := time.Parse(time.RFC3339, c.Date); err == nil {
+			return t, true
+		}
+	}
+	if c.Timestamp > 0 {
+		// Timestamps may be in seconds or milliseconds
+		if c.Timestamp > 1e12 {
+			return time.UnixMilli(c.Timestamp), true
+		}
+		return time.Unix(c.Timestamp, 0), true
+	}
+	return time.Time{}, false
+}
+
+// filterCommitsByTime keeps commits made between since and until. Zero
+// bounds are ignored. When a bound is set, commits with unparseable dates
+// are excluded because they cannot be placed in the window.
+func filterCommitsByTime(commits []model.Commit, since, until time.Time) []model.Commit {
+	if since.IsZero() && until.IsZero() {
+		return commits
+	}
+
+	filtered := make([]model.Commit, 0, len(commits))
+	for _, c := range commits {
+		t, ok := commitTime(c)
+		if !ok {
+			continue
+		}
+		if !since.IsZero() && t.Before(since) {
+			continue
+		}
+		if !until.IsZero() && t.After(until) {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
+
+// logPlaceholders maps --format placeholders to commit field extractors.
+var logPlaceholders = map[string]func(c model.Commit) string{
+	"H":  commitSHA,
+	"h":  func(c model.Commit) string { return shortSHA(commitSHA(c)) },
+	"s":  commitSubject,
+	"an": commitAuthor,
+	"ad": func(c model.Commit) string { return formatDateTime(c.Date) },
+}
+
+// nextLogPlaceholder returns the placeholder at the start of s, if any.
+// Longer placeholders are matched first so %an is not read as %a.
+func nextLogPlaceholder(s string) (string, bool) {
+	for _, p := range []string{"an", "ad", "H", "h", "s"} {
+		if strings.HasPrefix(s, p) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// validateLogFormat returns an error if format contains unknown placeholders.
+func validateLogFormat(format string) error {
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		rest := format[i+1:]
+		if strings.HasPrefix(rest, "%") {
+			i++
+			continue
+		}
+		p, ok := nextLogPlaceholder(rest)
+		if !ok {
+			return fmt.Errorf("unknown placeholder in --format at %q (supported: %%H, %%h, %%s, %%an, %%ad)", "%"+truncate(rest, 2))
+		}
+		i += len(p)
+	}
+	return nil
+}
+
+// formatLogCommit renders a commit using a validated --format template.
+func formatLogCommit(format string, c model.Commit) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		rest := format[i+1:]
+		if strings.HasPrefix(rest, "%") {
+			b.WriteByte('%')
+			i++
+			continue
+		}
+		if p, ok := nextLogPlaceholder(rest); ok {
+			b.WriteString(logPlaceholders[p](c))
+			i += len(p)
+			continue
+		}
+		b.WriteByte('%')
+	}
+	return b.String()
+}
+
+// commitSHA returns the full sha of a commit.
+func commitSHA(c model.Commit) string {
+	if c.SHA != "" {
+		return c.SHA
+	}
+	return c.Commit
+}
+
+// shortSHA abbreviates a sha to 7 characters.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// commitSubject returns the first line of a commit message.
+func commitSubject(c model.Commit) string {
+	return firstLine(c.Message)
+}
+
+// firstLine returns the first line of s without surrounding whitespace.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSpace(line)
+}
+
+// commitAuthor returns the display name of a commit's author.
+func commitAuthor(c model.Commit) string {
+	if c.Author.Name != "" {
+		return c.Author.Name
+	}
+	return c.Author.Raw
+}
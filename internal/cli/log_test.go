@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestValidateLogFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{format: "%H", wantErr: false},
+		{format: "%h %s (%an, %ad)", wantErr: false},
+		{format: "100%% done", wantErr: false},
+		{format: "no placeholders", wantErr: false},
+		{format: "%x", wantErr: true},
+		{format: "%a", wantErr: true},
+		{format: "trailing %", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := validateLogFormat(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateLogFormat(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatLogCommit(t *testing.T) {
+	c := model.Commit{
+		SHA:     "abcdef1234567890",
+		Message: "Fix bug",
+		Author:  model.CommitAuthor{Name: "Jane"},
+		Date:    "2024-03-15T10:30:00Z",
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "%H", want: "abcdef1234567890"},
+		{format: "%h %s", want: "abcdef1 Fix bug"},
+		{format: "%an <%ad>", want: "Jane <Mar 15, 2024 10:30>"},
+		{format: "%h 100%%", want: "abcdef1 100%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got := formatLogCommit(tt.format, c)
+			if got != tt.want {
+				t.Errorf("formatLogCommit(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "", want: time.Time{}},
+		{input: "2024-03-01T00:00:00Z", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "24h", want: now.Add(-24 * time.Hour)},
+		{input: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{input: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseTimeBound(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTimeBound(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseTimeBound(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterCommitsByTime(t *testing.T) {
+	commits := []model.Commit{
+		{SHA: "old", Date: "2024-03-01T00:00:00Z"},
+		{SHA: "mid", Date: "2024-03-10T00:00:00Z"},
+		{SHA: "new", Timestamp: time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC).Unix()},
+		{SHA: "undated"},
+	}
+
+	since := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		since time.Time
+		until time.Time
+		want  []string
+	}{
+		{name: "no filter", want: []string{"old", "mid", "new", "undated"}},
+		{name: "since", since: since, want: []string{"mid", "new"}},
+		{name: "until", until: until, want: []string{"old", "mid"}},
+		{name: "window", since: since, until: until, want: []string{"mid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterCommitsByTime(commits, tt.since, tt.until)
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterCommitsByTime() returned %d commits, want %d", len(got), len(tt.want))
+			}
+			for i, c := range got {
+				if c.SHA != tt.want[i] {
+					t.Errorf("commit %d = %s, want %s", i, c.SHA, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// captureStdout returns everything fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, fn)
+}
+
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stderr, fn)
+}
+
+func captureFile(t *testing.T, f **os.File, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := *f
+	*f = w
+	defer func() { *f = orig }()
+
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestLogNoColor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]model.Commit{
+			{SHA: "abcdef1234567890", Message: "Fix bug", Author: model.CommitAuthor{Name: "Jane"}, Date: "2024-03-15T10:30:00Z"},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("NO_COLOR", "1")
+
+	for _, args := range [][]string{{"s/r"}, {"s/r", "--oneline"}} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			cmd := newLogCmd()
+			cmd.SetArgs(args)
+			out := captureStdout(t, func() {
+				if err := cmd.Execute(); err != nil {
+					t.Errorf("log %v error = %v", args, err)
+				}
+			})
+			if !strings.Contains(out, "abcdef1") {
+				t.Errorf("log %v output = %q, want short sha", args, out)
+			}
+			if strings.Contains(out, "\x1b[") {
+				t.Errorf("log %v output contains escape codes: %q", args, out)
+			}
+		})
+	}
+}
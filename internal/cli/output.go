@@ -1,16 +1,21 @@
 package cli
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/morrisclay/scraps-cli/internal/config"
 	"github.com/morrisclay/scraps-cli/internal/tui/components"
 	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
 )
 
 // isInteractive returns true if stdout is a terminal.
@@ -18,36 +23,257 @@ func isInteractive() bool {
 	return term.IsTerminal(int(os.Stdout.Fd()))
 }
 
+// noColor is the global --no-color flag.
+var noColor bool
+
+// colorEnabled reports whether ANSI colors should be written to stdout.
+// Color is off with --no-color, when NO_COLOR is set, when TERM is "dumb"
+// or when stdout is not a terminal.
+func colorEnabled() bool {
+	if noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return isInteractive()
+}
+
+// colorize wraps s in an ANSI color code if color output is enabled.
+func colorize(color, s string) string {
+	if !colorEnabled() {
+		return s
+	}
+	return color + s + colorReset
+}
+
 // isInputInteractive returns true if stdin is a terminal.
 func isInputInteractive() bool {
 	return term.IsTerminal(int(os.Stdin.Fd()))
 }
 
+// withLoading runs fn behind a spinner when stdout is a terminal. Structured
+// and non-interactive output call fn directly so nothing extra is printed.
+func withLoading[T any](message string, fn func() (T, error)) (T, error) {
+	if !isInteractive() || isStructuredOutput() || quiet {
+		return fn()
+	}
+	return components.RunWithLoading(message, fn)
+}
+
 // outputJSON outputs data as formatted JSON.
-func outputJSON(data any) {
+func outputJSON(data any) error {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
-	enc.Encode(data)
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to write JSON output: %w", err)
+	}
+	return nil
+}
+
+// outputYAML outputs data as YAML.
+// Data is round-tripped through JSON so field names match the json tags.
+func outputYAML(data any) error {
+	v, err := jsonValue(data)
+	if err != nil {
+		return fmt.Errorf("failed to write YAML output: %w", err)
+	}
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write YAML output: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to write YAML output: %w", err)
+	}
+	return nil
+}
+
+// jsonValue round-trips data through JSON, returning it as the maps,
+// slices and scalars encoding/json decodes to.
+func jsonValue(data any) (any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// isStructuredOutput returns true if the output format is machine-readable.
+func isStructuredOutput() bool {
+	switch config.GetOutputFormat() {
+	case "json", "yaml", "csv", "tsv":
+		return true
+	}
+	return false
+}
+
+// isDelimitedOutput returns true for the csv and tsv formats. Commands that
+// list rows write them with outputTable, which honours these formats;
+// anything else falls back to key,value rows in outputStructured.
+func isDelimitedOutput() bool {
+	switch config.GetOutputFormat() {
+	case "csv", "tsv":
+		return true
+	}
+	return false
+}
+
+// outputStructured outputs data in the configured machine-readable format.
+func outputStructured(data any) error {
+	switch config.GetOutputFormat() {
+	case "yaml":
+		return outputYAML(data)
+	case "csv", "tsv":
+		return outputKeyValues(data)
+	default:
+		return outputJSON(data)
+	}
+}
+
+// outputDelimited writes a header line and rows as CSV, or as TSV with the
+// tsv format. Fields are quoted as encoding/csv requires.
+func outputDelimited(headers []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	if config.GetOutputFormat() == "tsv" {
+		w.Comma = '\t'
+	}
+	w.Write(headers)
+	w.WriteAll(rows)
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write %s output: %w", config.GetOutputFormat(), err)
+	}
+	return nil
 }
 
-// outputTable outputs data as a table.
-func outputTable(headers []string, rows [][]string) {
+// outputKeyValues writes data without a table form as delimited rows. An
+// object becomes key,value rows sorted by key and a list of objects one row
+// per element; nested values are written as JSON.
+func outputKeyValues(data any) error {
+	v, err := jsonValue(data)
+	if err != nil {
+		return fmt.Errorf("failed to write %s output: %w", config.GetOutputFormat(), err)
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		keys := slices.Sorted(maps.Keys(v))
+		rows := make([][]string, len(keys))
+		for i, k := range keys {
+			rows[i] = []string{k, delimitedValue(v[k])}
+		}
+		return outputDelimited([]string{"key", "value"}, rows)
+
+	case []any:
+		var headers []string
+		for _, item := range v {
+			if obj, ok := item.(map[string]any); ok {
+				for k := range obj {
+					if !slices.Contains(headers, k) {
+						headers = append(headers, k)
+					}
+				}
+			}
+		}
+		if len(headers) == 0 {
+			headers = []string{"value"}
+		}
+		slices.Sort(headers)
+
+		rows := make([][]string, len(v))
+		for i, item := range v {
+			obj, ok := item.(map[string]any)
+			if !ok {
+				rows[i] = []string{delimitedValue(item)}
+				continue
+			}
+			rows[i] = make([]string, len(headers))
+			for j, k := range headers {
+				rows[i][j] = delimitedValue(obj[k])
+			}
+		}
+		return outputDelimited(headers, rows)
+
+	default:
+		return outputDelimited([]string{"value"}, [][]string{{delimitedValue(v)}})
+	}
+}
+
+// delimitedValue formats a decoded JSON value for a delimited field.
+func delimitedValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	raw, _ := json.Marshal(v)
+	return string(raw)
+}
+
+// maskSecret hides most of an API key when the mask_secrets config option is
+// on and --reveal was not passed. Otherwise the key is returned unchanged.
+func maskSecret(secret string) string {
+	if revealSecrets || !config.GetMaskSecrets() {
+		return secret
+	}
+	return maskValue(secret)
+}
+
+// maskSecretIn masks every occurrence of secret within text, such as an
+// API key embedded in a clone URL.
+func maskSecretIn(text, secret string) string {
+	if secret == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, secret, maskSecret(secret))
+}
+
+// maskValue masks a secret as "<prefix>_****…<last4>", keeping the key
+// prefix (e.g. "scraps_") so the kind of key is still recognizable.
+func maskValue(secret string) string {
+	if len(secret) <= 8 {
+		return "****"
+	}
+	prefix := ""
+	if i := strings.Index(secret, "_"); i >= 0 && i < len(secret)-8 {
+		prefix = secret[:i+1]
+	}
+	return prefix + "****…" + secret[len(secret)-4:]
+}
+
+// noTruncate is the global --no-truncate flag.
+var noTruncate bool
+
+// minColumnWidth is the narrowest a column is truncated to when fitting a
+// table to the terminal.
+const minColumnWidth = 6
+
+// outputTable outputs data as a table. On a terminal, the widest columns
+// are truncated so each line fits its width, unless --no-truncate is set.
+// With the csv and tsv formats the rows are written delimited instead.
+func outputTable(headers []string, rows [][]string) error {
+	if isDelimitedOutput() {
+		return outputDelimited(headers, rows)
+	}
 	if len(rows) == 0 {
-		return
+		return nil
 	}
 
 	// Calculate column widths
 	widths := make([]int, len(headers))
 	for i, h := range headers {
-		widths[i] = len(h)
+		widths[i] = utf8.RuneCountInString(h)
 	}
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(widths) && len(cell) > widths[i] {
-				widths[i] = len(cell)
+			if n := utf8.RuneCountInString(cell); i < len(widths) && n > widths[i] {
+				widths[i] = n
 			}
 		}
 	}
+	widths = fitWidths(widths, tableWidthLimit())
 
 	// Print header
 	headerLine := ""
@@ -55,7 +281,7 @@ func outputTable(headers []string, rows [][]string) {
 		if i > 0 {
 			headerLine += "  "
 		}
-		headerLine += fmt.Sprintf("%-*s", widths[i], h)
+		headerLine += fmt.Sprintf("%-*s", widths[i], elide(h, widths[i]))
 	}
 	fmt.Println(headerLine)
 
@@ -80,19 +306,144 @@ func outputTable(headers []string, rows [][]string) {
 			if i < len(row) {
 				cell = row[i]
 			}
-			rowLine += fmt.Sprintf("%-*s", widths[i], cell)
+			rowLine += fmt.Sprintf("%-*s", widths[i], elide(cell, widths[i]))
 		}
 		fmt.Println(rowLine)
 	}
+	return nil
 }
 
-// output outputs data as JSON or table based on config.
-func output(data any, headers []string, rows [][]string) {
-	format := config.GetOutputFormat()
-	if format == "json" {
-		outputJSON(data)
-	} else {
-		outputTable(headers, rows)
+// tableWidthLimit returns the width tables must fit in: the terminal's
+// width, or 0 for no limit when stdout is not a terminal or --no-truncate
+// is set.
+func tableWidthLimit() int {
+	if noTruncate || !isInteractive() {
+		return 0
+	}
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+// fitWidths narrows the widest columns until a table with two spaces
+// between columns fits in limit, leaving narrower columns intact. Columns
+// are not narrowed below minColumnWidth, so a very narrow terminal can
+// still wrap. A limit of 0 means no limit.
+func fitWidths(widths []int, limit int) []int {
+	if limit <= 0 || len(widths) == 0 {
+		return widths
+	}
+	total := func(maxWidth int) int {
+		n := 2 * (len(widths) - 1)
+		for _, w := range widths {
+			n += min(w, maxWidth)
+		}
+		return n
+	}
+
+	maxWidth := slices.Max(widths)
+	for maxWidth > minColumnWidth && total(maxWidth) > limit {
+		maxWidth--
+	}
+
+	fitted := make([]int, len(widths))
+	for i, w := range widths {
+		fitted[i] = min(w, maxWidth)
+	}
+	return fitted
+}
+
+// elide shortens s to width characters, ending it with "…" if it was cut.
+func elide(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string([]rune(s)[:width])
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+// fieldName is the --fields name of a column header, e.g. "last_used" for
+// "LAST USED".
+func fieldName(header string) string {
+	return strings.ReplaceAll(strings.ToLower(header), " ", "_")
+}
+
+// validateFields checks that every field names one of the headers.
+func validateFields(headers, fields []string) error {
+	for _, f := range fields {
+		if !slices.ContainsFunc(headers, func(h string) bool { return fieldName(h) == fieldName(f) }) {
+			names := make([]string, len(headers))
+			for i, h := range headers {
+				names[i] = fieldName(h)
+			}
+			return fmt.Errorf("unknown field %q (available: %s)", f, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// selectFields restricts headers and rows to the named fields, in the order
+// given. Field names match headers case-insensitively, with "_" for spaces.
+func selectFields(headers []string, rows [][]string, fields []string) ([]string, [][]string, error) {
+	if err := validateFields(headers, fields); err != nil {
+		return nil, nil, err
+	}
+
+	cols := make([]int, len(fields))
+	selected := make([]string, len(fields))
+	for i, f := range fields {
+		cols[i] = slices.IndexFunc(headers, func(h string) bool { return fieldName(h) == fieldName(f) })
+		selected[i] = headers[cols[i]]
+	}
+
+	selectedRows := make([][]string, len(rows))
+	for i, row := range rows {
+		selectedRows[i] = make([]string, len(cols))
+		for j, c := range cols {
+			if c < len(row) {
+				selectedRows[i][j] = row[c]
+			}
+		}
+	}
+	return selected, selectedRows, nil
+}
+
+// outputFields writes the columns named by --fields: in JSON and YAML as
+// one object per row keyed by field name, otherwise as a table.
+func outputFields(headers []string, rows [][]string, fields []string) error {
+	headers, rows, err := selectFields(headers, rows, fields)
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput() && !isDelimitedOutput() {
+		records := make([]map[string]string, len(rows))
+		for i, row := range rows {
+			records[i] = make(map[string]string, len(headers))
+			for j, h := range headers {
+				records[i][fieldName(h)] = row[j]
+			}
+		}
+		return outputStructured(records)
+	}
+	return outputTable(headers, rows)
+}
+
+// output outputs data as JSON, YAML, CSV, TSV or table based on config.
+func output(data any, headers []string, rows [][]string) error {
+	switch config.GetOutputFormat() {
+	case "json":
+		return outputJSON(data)
+	case "yaml":
+		return outputYAML(data)
+	case "csv", "tsv":
+		return outputDelimited(headers, rows)
+	default:
+		return outputTable(headers, rows)
 	}
 }
 
@@ -114,6 +465,79 @@ func formatDateTime(dateStr string) string {
 	return t.Format("Jan 02, 2006 15:04")
 }
 
+// formatRelative formats a date string relative to now, such as
+// "3 days ago" or "in 5 minutes".
+func formatRelative(dateStr string) string {
+	t, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return dateStr
+	}
+	return relativeTime(t, time.Now())
+}
+
+// relativeTime describes t relative to now in its largest whole unit.
+// Months are 30 days and years 365.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	const day = 24 * time.Hour
+	var s string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		s = plural(int(d/time.Minute), "minute")
+	case d < day:
+		s = plural(int(d/time.Hour), "hour")
+	case d < 30*day:
+		s = plural(int(d/day), "day")
+	case d < 365*day:
+		s = plural(int(d/(30*day)), "month")
+	default:
+		s = plural(int(d/(365*day)), "year")
+	}
+
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
+// plural formats a count with its unit, e.g. "1 day" or "3 days".
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// useRelativeDates reports whether table dates should be relative, set by
+// --relative or the relative_dates config option.
+func useRelativeDates() bool {
+	return relativeDates || config.GetRelativeDates()
+}
+
+// formatTableDate formats a date for a table column: relative with
+// --relative, otherwise as formatDate.
+func formatTableDate(dateStr string) string {
+	if useRelativeDates() {
+		return formatRelative(dateStr)
+	}
+	return formatDate(dateStr)
+}
+
+// formatTableDateTime is formatTableDate for columns that show the time.
+func formatTableDateTime(dateStr string) string {
+	if useRelativeDates() {
+		return formatRelative(dateStr)
+	}
+	return formatDateTime(dateStr)
+}
+
 // formatTime formats a time for display.
 func formatTime(t time.Time) string {
 	return t.Format("15:04:05")
@@ -131,8 +555,12 @@ func truncate(s string, maxLen int) string {
 }
 
 // outputInteractiveTable outputs data as an interactive table with selection.
-// Returns the selected row, or nil if cancelled.
+// Returns the selected row, or nil if cancelled. With the csv and tsv
+// formats the rows are written delimited and nothing is selected.
 func outputInteractiveTable(title string, headers []string, rows [][]string) (table.Row, error) {
+	if isDelimitedOutput() {
+		return nil, outputDelimited(headers, rows)
+	}
 	if len(rows) == 0 {
 		return nil, nil
 	}
@@ -177,18 +605,15 @@ func outputInteractiveTable(title string, headers []string, rows [][]string) (ta
 }
 
 // outputWithInteractiveTable outputs data with optional interactive table.
-// If interactive and not JSON format, shows interactive table; otherwise shows static table.
+// If interactive and not a structured format, shows interactive table; otherwise shows static table.
 func outputWithInteractiveTable(title string, data any, headers []string, rows [][]string) (table.Row, error) {
-	format := config.GetOutputFormat()
-	if format == "json" {
-		outputJSON(data)
-		return nil, nil
+	if isStructuredOutput() && !isDelimitedOutput() {
+		return nil, outputStructured(data)
 	}
 
 	if isInteractive() && len(rows) > 0 {
 		return outputInteractiveTable(title, headers, rows)
 	}
 
-	outputTable(headers, rows)
-	return nil, nil
+	return nil, outputTable(headers, rows)
 }
@@ -1,7 +1,12 @@
 package cli
 
 import (
+	"os"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/morrisclay/scraps-cli/internal/config"
 )
 
 func TestTruncate(t *testing.T) {
@@ -53,6 +58,49 @@ func TestTruncate(t *testing.T) {
 	}
 }
 
+func TestFitWidths(t *testing.T) {
+	tests := []struct {
+		name   string
+		widths []int
+		limit  int
+		want   []int
+	}{
+		{name: "no limit", widths: []int{40, 10}, limit: 0, want: []int{40, 10}},
+		{name: "fits", widths: []int{20, 10}, limit: 32, want: []int{20, 10}},
+		{name: "widest shrinks", widths: []int{40, 10, 8}, limit: 40, want: []int{18, 10, 8}},
+		{name: "wide columns share", widths: []int{30, 30, 4}, limit: 40, want: []int{16, 16, 4}},
+		{name: "minimum width", widths: []int{30, 30}, limit: 10, want: []int{minColumnWidth, minColumnWidth}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fitWidths(tt.widths, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fitWidths(%v, %d) = %v, want %v", tt.widths, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestElide(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{s: "hello", width: 10, want: "hello"},
+		{s: "hello", width: 5, want: "hello"},
+		{s: "hello world", width: 6, want: "hello…"},
+		{s: "héllo wörld", width: 4, want: "hél…"},
+		{s: "hello", width: 1, want: "h"},
+	}
+
+	for _, tt := range tests {
+		if got := elide(tt.s, tt.width); got != tt.want {
+			t.Errorf("elide(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
 func TestFormatDate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -113,3 +161,264 @@ func TestFormatDateTime(t *testing.T) {
 		})
 	}
 }
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name string
+		ago  time.Duration
+		want string
+	}{
+		{name: "now", ago: 0, want: "just now"},
+		{name: "seconds", ago: 59 * time.Second, want: "just now"},
+		{name: "one minute", ago: time.Minute, want: "1 minute ago"},
+		{name: "minutes", ago: 59 * time.Minute, want: "59 minutes ago"},
+		{name: "one hour", ago: time.Hour, want: "1 hour ago"},
+		{name: "hours", ago: 23 * time.Hour, want: "23 hours ago"},
+		{name: "one day", ago: day, want: "1 day ago"},
+		{name: "days", ago: 29 * day, want: "29 days ago"},
+		{name: "one month", ago: 30 * day, want: "1 month ago"},
+		{name: "months", ago: 364 * day, want: "12 months ago"},
+		{name: "one year", ago: 365 * day, want: "1 year ago"},
+		{name: "years", ago: 800 * day, want: "2 years ago"},
+		{name: "future", ago: -5 * time.Minute, want: "in 5 minutes"},
+		{name: "future days", ago: -3 * day, want: "in 3 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeTime(now.Add(-tt.ago), now); got != tt.want {
+				t.Errorf("relativeTime(now - %v) = %q, want %q", tt.ago, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTableDate(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	date := time.Now().Add(-3 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	if got, want := formatTableDate(date), formatDate(date); got != want {
+		t.Errorf("formatTableDate() = %q by default, want %q", got, want)
+	}
+	if got := formatTableDate("not-a-date"); got != "not-a-date" {
+		t.Errorf("formatTableDate() = %q for an invalid date, want it unchanged", got)
+	}
+
+	relativeDates = true
+	defer func() { relativeDates = false }()
+	if got := formatTableDate(date); got != "3 days ago" {
+		t.Errorf("formatTableDate() = %q with --relative, want %q", got, "3 days ago")
+	}
+	relativeDates = false
+
+	if err := config.SetValue("relative_dates", "true"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if got := formatTableDateTime(date); got != "3 days ago" {
+		t.Errorf("formatTableDateTime() = %q with relative_dates on, want %q", got, "3 days ago")
+	}
+}
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		secret string
+		want   string
+	}{
+		{secret: "scraps_abcdefghijkl1234", want: "scraps_****…1234"},
+		{secret: "abcdefghijkl1234", want: "****…1234"},
+		{secret: "short", want: "****"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.secret, func(t *testing.T) {
+			if got := maskValue(tt.secret); got != tt.want {
+				t.Errorf("maskValue(%q) = %q, want %q", tt.secret, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	secret := "scraps_abcdefghijkl1234"
+
+	if got := maskSecret(secret); got != secret {
+		t.Errorf("maskSecret() = %q with mask_secrets off, want unchanged", got)
+	}
+
+	if err := config.SetValue("mask_secrets", "true"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if got := maskSecret(secret); got != "scraps_****…1234" {
+		t.Errorf("maskSecret() = %q with mask_secrets on, want masked", got)
+	}
+
+	revealSecrets = true
+	defer func() { revealSecrets = false }()
+	if got := maskSecret(secret); got != secret {
+		t.Errorf("maskSecret() = %q with --reveal, want unchanged", got)
+	}
+}
+
+func TestOutputDelimited(t *testing.T) {
+	headers := []string{"NAME", "LABEL"}
+	rows := [][]string{
+		{"plain", "no quoting"},
+		{"comma", "ci, deploy"},
+		{"quote", `say "hi"`},
+		{"tab", "a\tb"},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "csv",
+			want:   "NAME,LABEL\nplain,no quoting\ncomma,\"ci, deploy\"\nquote,\"say \"\"hi\"\"\"\ntab,a\tb\n",
+		},
+		{
+			format: "tsv",
+			want:   "NAME\tLABEL\nplain\tno quoting\ncomma\tci, deploy\nquote\t\"say \"\"hi\"\"\"\ntab\t\"a\tb\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Setenv("SCRAPS_OUTPUT_FORMAT", tt.format)
+			got := captureStdout(t, func() { outputTable(headers, rows) })
+			if got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputDelimitedWriteError(t *testing.T) {
+	// A read-only stdout makes every write fail
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	stdout := os.Stdout
+	os.Stdout = f
+	defer func() { os.Stdout = stdout }()
+
+	for _, format := range []string{"csv", "tsv"} {
+		t.Run(format, func(t *testing.T) {
+			t.Setenv("SCRAPS_OUTPUT_FORMAT", format)
+			if err := outputTable([]string{"NAME"}, [][]string{{"a"}}); err == nil {
+				t.Error("outputTable() error = nil, want the write error")
+			}
+			if err := output(nil, []string{"NAME"}, [][]string{{"a"}}); err == nil {
+				t.Error("output() error = nil, want the write error")
+			}
+			if err := outputStructured(map[string]string{"name": "a"}); err == nil {
+				t.Error("outputStructured() error = nil, want the write error")
+			}
+		})
+	}
+}
+
+func TestOutputKeyValues(t *testing.T) {
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "csv")
+
+	tests := []struct {
+		name string
+		data any
+		want string
+	}{
+		{
+			name: "object",
+			data: struct {
+				Username string   `json:"username"`
+				Stores   []string `json:"stores"`
+				Admin    bool     `json:"admin"`
+			}{Username: "ada, l.", Stores: []string{"a", "b"}, Admin: true},
+			want: "key,value\nadmin,true\nstores,\"[\"\"a\"\",\"\"b\"\"]\"\nusername,\"ada, l.\"\n",
+		},
+		{
+			name: "list of objects",
+			data: []map[string]any{{"name": "a", "size": 1}, {"name": "b"}},
+			want: "name,size\na,1\nb,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := captureStdout(t, func() { outputStructured(tt.data) })
+			if got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputStructuredError(t *testing.T) {
+	// Channels cannot be encoded, so nothing is written
+	data := map[string]any{"events": make(chan int)}
+
+	for _, format := range []string{"json", "yaml", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			t.Setenv("SCRAPS_OUTPUT_FORMAT", format)
+			var err error
+			out := captureStdout(t, func() { err = outputStructured(data) })
+			if err == nil {
+				t.Errorf("outputStructured() error = nil, want an encoding error (output %q)", out)
+			}
+		})
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	headers := []string{"ID", "LABEL", "LAST USED"}
+	rows := [][]string{{"k1", "ci", "today"}, {"k2", "laptop"}}
+
+	tests := []struct {
+		name        string
+		fields      []string
+		wantHeaders []string
+		wantRows    [][]string
+		wantErr     string
+	}{
+		{
+			name:        "reorders",
+			fields:      []string{"label", "id"},
+			wantHeaders: []string{"LABEL", "ID"},
+			wantRows:    [][]string{{"ci", "k1"}, {"laptop", "k2"}},
+		},
+		{
+			name:        "spaces as underscores",
+			fields:      []string{"LAST_USED"},
+			wantHeaders: []string{"LAST USED"},
+			wantRows:    [][]string{{"today"}, {""}},
+		},
+		{
+			name:    "unknown field",
+			fields:  []string{"id", "owner"},
+			wantErr: `unknown field "owner" (available: id, label, last_used)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHeaders, gotRows, err := selectFields(headers, rows, tt.fields)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("selectFields() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectFields() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotHeaders, tt.wantHeaders) || !reflect.DeepEqual(gotRows, tt.wantRows) {
+				t.Errorf("selectFields() = %v, %v; want %v, %v", gotHeaders, gotRows, tt.wantHeaders, tt.wantRows)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+)
+
+// pingResult is the structured output of the ping command.
+type pingResult struct {
+	OK            bool   `json:"ok"`
+	LatencyMS     int64  `json:"latency_ms"`
+	Authenticated bool   `json:"authenticated"`
+	Host          string `json:"host"`
+	Username      string `json:"username,omitempty"`
+}
+
+func newPingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Check that the API is reachable and the credentials work",
+		Long: `Check that the API is reachable and the credentials work.
+
+A single request is made for the current user, and the round-trip latency
+and authentication status are printed. The command exits non-zero when the
+host cannot be reached (5) or the credentials are missing or rejected (2),
+so it can guard a batch of operations in a script.`,
+		Example: `  scraps ping
+  scraps ping --host https://scraps.example.com
+  scraps ping -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			host := api.DefaultHost()
+			loggedIn := true
+			client, err := api.NewClientFromConfig("")
+			if errors.Is(err, api.ErrNotLoggedIn) {
+				// Still check that the host answers
+				loggedIn = false
+				client = api.NewClient(host, "")
+			} else if err != nil {
+				return err
+			}
+
+			result, err := ping(client)
+			if err != nil {
+				var apiErr *api.APIError
+				if !errors.As(err, &apiErr) || !(apiErr.IsUnauthorized() || apiErr.IsForbidden()) {
+					return err
+				}
+				if !loggedIn {
+					err = fmt.Errorf("%w to %s", api.ErrNotLoggedIn, client.Host())
+				}
+			}
+
+			if isStructuredOutput() {
+				if err := outputStructured(result); err != nil {
+					return err
+				}
+			} else {
+				fmt.Printf("Host: %s\n", result.Host)
+				fmt.Printf("Latency: %dms\n", result.LatencyMS)
+				switch {
+				case result.Authenticated:
+					fmt.Printf("Auth: logged in as %s\n", result.Username)
+				case !loggedIn:
+					fmt.Println("Auth: not logged in")
+				default:
+					fmt.Println("Auth: invalid credentials")
+				}
+			}
+
+			if err != nil {
+				return &reportedError{cause: err}
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+// ping times a request for the current user. The result is filled in even
+// when the API rejects the credentials, so the latency can still be shown;
+// a nil result means the host could not be reached.
+func ping(client *api.Client) (*pingResult, error) {
+	start := time.Now()
+	user, err := client.GetUser()
+	latency := time.Since(start)
+
+	result := &pingResult{Host: client.Host(), LatencyMS: latency.Milliseconds()}
+	if err != nil {
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) {
+			return nil, err
+		}
+		return result, err
+	}
+
+	result.OK = true
+	result.Authenticated = true
+	result.Username = user.Username
+	return result, nil
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name      string
+		apiKey    string
+		status    int
+		wantOK    bool
+		wantAuth  bool
+		wantExit  int
+		wantReply bool // whether a result is printed
+	}{
+		{name: "valid key", apiKey: "good", status: http.StatusOK, wantOK: true, wantAuth: true, wantExit: exitOK, wantReply: true},
+		{name: "rejected key", apiKey: "bad", status: http.StatusUnauthorized, wantExit: exitAuth, wantReply: true},
+		{name: "not logged in", status: http.StatusUnauthorized, wantExit: exitAuth, wantReply: true},
+		{name: "server error", apiKey: "good", status: http.StatusInternalServerError, wantExit: exitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/user" {
+					t.Errorf("unexpected request %s", r.URL.Path)
+				}
+				if tt.status != http.StatusOK {
+					w.WriteHeader(tt.status)
+					w.Write([]byte(`{"error": "nope"}`))
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]string{"id": "u1", "username": "alice"})
+			}))
+			defer server.Close()
+
+			t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+			t.Setenv("SCRAPS_HOST", server.URL)
+			t.Setenv("SCRAPS_API_KEY", tt.apiKey)
+			t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+			cmd := newPingCmd()
+			cmd.SetArgs(nil)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			var err error
+			out := captureStdout(t, func() {
+				err = cmd.Execute()
+				if err != nil && tt.wantReply {
+					// Already described by the result, so nothing more is printed
+					reportError(err)
+				}
+			})
+
+			if got := exitCode(err); got != tt.wantExit {
+				t.Errorf("exit code = %d (%v), want %d", got, err, tt.wantExit)
+			}
+			if !tt.wantReply {
+				if out != "" {
+					t.Errorf("output = %q, want none", out)
+				}
+				return
+			}
+
+			var result pingResult
+			if err := json.Unmarshal([]byte(out), &result); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, out)
+			}
+			if result.OK != tt.wantOK || result.Authenticated != tt.wantAuth {
+				t.Errorf("result = %+v, want ok=%v authenticated=%v", result, tt.wantOK, tt.wantAuth)
+			}
+			if tt.wantAuth && result.Username != "alice" {
+				t.Errorf("Username = %q, want alice", result.Username)
+			}
+		})
+	}
+}
+
+func TestPingUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "good")
+
+	cmd := newPingCmd()
+	cmd.SetArgs(nil)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	var err error
+	captureStdout(t, func() { err = cmd.Execute() })
+	if got := exitCode(err); got != exitNetwork {
+		t.Errorf("exit code = %d (%v), want %d", got, err, exitNetwork)
+	}
+}
@@ -2,24 +2,99 @@ package cli
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/morrisclay/scraps-cli/internal/config"
 	"github.com/morrisclay/scraps-cli/internal/model"
 )
 
+// slugPattern matches valid store slugs: lowercase letters, digits, hyphens
+// and underscores, starting with a letter or digit.
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// validateSlug returns an error if slug is not a valid store slug.
+func validateSlug(slug string) error {
+	if !slugPattern.MatchString(slug) {
+		return fmt.Errorf("invalid slug %q: use up to 64 lowercase letters, digits, hyphens or underscores, starting with a letter or digit", slug)
+	}
+	return nil
+}
+
+// splitStoreRepo splits "store/repo". A bare "repo" resolves against the
+// default_store config value when one is set.
+func splitStoreRepo(storeRepo string) (store, repo string, ok bool) {
+	if store, repo, found := strings.Cut(storeRepo, "/"); found {
+		return store, repo, true
+	}
+	if store := config.GetDefaultStore(); store != "" && storeRepo != "" {
+		return store, storeRepo, true
+	}
+	return "", "", false
+}
+
 // parseStoreRepo parses a "store/repo" reference.
 func parseStoreRepo(ref string) (store, repo string, err error) {
-	parts := strings.SplitN(ref, "/", 2)
-	if len(parts) != 2 {
+	store, repo, ok := splitStoreRepo(ref)
+	if !ok {
 		return "", "", fmt.Errorf("invalid reference: expected store/repo, got %q", ref)
 	}
-	return parts[0], parts[1], nil
+	return store, repo, nil
+}
+
+// normalizeBranchSeparator rewrites "store/repo@branch[:path]" to the
+// equivalent "store/repo:branch[:path]". The "@" form is recognized only
+// before the first colon, since store and repo slugs never contain "@"; the
+// branch then runs up to the first colon and the path is the rest.
+func normalizeBranchSeparator(ref string) string {
+	head, rest, hasPath := strings.Cut(ref, ":")
+	storeRepo, branch, ok := strings.Cut(head, "@")
+	if !ok {
+		return ref
+	}
+	ref = storeRepo + ":" + branch
+	if hasPath {
+		ref += ":" + rest
+	}
+	return ref
+}
+
+// splitCommit removes a "#sha" commit pin from ref and returns the rest in
+// the colon form along with the commit. The pin may follow the repo
+// ("store/repo#sha[:path]", in which case there is no branch) or the branch
+// ("store/repo:branch#sha[:path]"); a "#" in the path is left alone.
+func splitCommit(ref string) (rest, commit string) {
+	parts := strings.SplitN(normalizeBranchSeparator(ref), ":", 3)
+	if head, commit, ok := strings.Cut(parts[0], "#"); ok {
+		// No branch, so anything after the pin is the path
+		if len(parts) > 1 {
+			head += "::" + strings.Join(parts[1:], ":")
+		}
+		return head, commit
+	}
+	if len(parts) > 1 {
+		if branch, commit, ok := strings.Cut(parts[1], "#"); ok {
+			parts[1] = branch
+			return strings.Join(parts, ":"), commit
+		}
+	}
+	return strings.Join(parts, ":"), ""
+}
+
+// pinnedRef returns the git ref to read at: commit if one was pinned,
+// otherwise branch.
+func pinnedRef(branch, commit string) string {
+	if commit != "" {
+		return commit
+	}
+	return branch
 }
 
-// parseStoreRepoBranch parses a "store/repo:branch" reference.
+// parseStoreRepoBranch parses a "store/repo:branch" or "store/repo@branch"
+// reference.
 func parseStoreRepoBranch(ref string) (store, repo, branch string, err error) {
 	// Split on colon first
-	colonParts := strings.SplitN(ref, ":", 2)
+	colonParts := strings.SplitN(normalizeBranchSeparator(ref), ":", 2)
 	storeRepo := colonParts[0]
 
 	if len(colonParts) == 2 {
@@ -27,18 +102,19 @@ func parseStoreRepoBranch(ref string) (store, repo, branch string, err error) {
 	}
 
 	// Split store/repo
-	slashParts := strings.SplitN(storeRepo, "/", 2)
-	if len(slashParts) != 2 {
+	store, repo, ok := splitStoreRepo(storeRepo)
+	if !ok {
 		return "", "", "", fmt.Errorf("invalid reference: expected store/repo[:branch], got %q", ref)
 	}
 
-	return slashParts[0], slashParts[1], branch, nil
+	return store, repo, branch, nil
 }
 
-// parseStoreRepoBranchPath parses a "store/repo:branch:path" reference.
+// parseStoreRepoBranchPath parses a "store/repo:branch:path" or
+// "store/repo@branch:path" reference.
 func parseStoreRepoBranchPath(ref string) (store, repo, branch, path string, err error) {
 	// Split on colons
-	parts := strings.SplitN(ref, ":", 3)
+	parts := strings.SplitN(normalizeBranchSeparator(ref), ":", 3)
 	if len(parts) < 2 {
 		return "", "", "", "", fmt.Errorf("invalid reference: expected store/repo:branch[:path], got %q", ref)
 	}
@@ -50,17 +126,18 @@ func parseStoreRepoBranchPath(ref string) (store, repo, branch, path string, err
 	}
 
 	// Split store/repo
-	slashParts := strings.SplitN(storeRepo, "/", 2)
-	if len(slashParts) != 2 {
+	store, repo, ok := splitStoreRepo(storeRepo)
+	if !ok {
 		return "", "", "", "", fmt.Errorf("invalid reference: expected store/repo:branch[:path], got %q", ref)
 	}
 
-	return slashParts[0], slashParts[1], branch, path, nil
+	return store, repo, branch, path, nil
 }
 
 // parseReference parses any reference format and returns a Reference.
 func parseReference(ref string) (*model.Reference, error) {
 	r := &model.Reference{}
+	ref, r.Commit = splitCommit(ref)
 
 	// Count colons to determine format
 	colonCount := strings.Count(ref, ":")
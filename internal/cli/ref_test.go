@@ -2,9 +2,14 @@ package cli
 
 import (
 	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/model"
 )
 
 func TestParseStoreRepo(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
 	tests := []struct {
 		name      string
 		ref       string
@@ -58,6 +63,8 @@ func TestParseStoreRepo(t *testing.T) {
 }
 
 func TestParseStoreRepoBranch(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
 	tests := []struct {
 		name       string
 		ref        string
@@ -90,6 +97,13 @@ func TestParseStoreRepoBranch(t *testing.T) {
 			wantBranch: "feature/new-thing",
 			wantErr:    false,
 		},
+		{
+			name:       "at separator",
+			ref:        "mystore/myrepo@feature/new-thing",
+			wantStore:  "mystore",
+			wantRepo:   "myrepo",
+			wantBranch: "feature/new-thing",
+		},
 		{
 			name:    "missing slash",
 			ref:     "mystore:main",
@@ -120,6 +134,8 @@ func TestParseStoreRepoBranch(t *testing.T) {
 }
 
 func TestParseStoreRepoBranchPath(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
 	tests := []struct {
 		name       string
 		ref        string
@@ -165,6 +181,37 @@ func TestParseStoreRepoBranchPath(t *testing.T) {
 			wantPath:   "file:with:colons.txt",
 			wantErr:    false,
 		},
+		{
+			name:       "at separator",
+			ref:        "mystore/myrepo@main:src/index.ts",
+			wantStore:  "mystore",
+			wantRepo:   "myrepo",
+			wantBranch: "main",
+			wantPath:   "src/index.ts",
+		},
+		{
+			name:       "at separator with slashed branch",
+			ref:        "mystore/myrepo@feature/x:src/a.go",
+			wantStore:  "mystore",
+			wantRepo:   "myrepo",
+			wantBranch: "feature/x",
+			wantPath:   "src/a.go",
+		},
+		{
+			name:       "at separator without path",
+			ref:        "mystore/myrepo@release/1.0",
+			wantStore:  "mystore",
+			wantRepo:   "myrepo",
+			wantBranch: "release/1.0",
+		},
+		{
+			name:       "at sign in path",
+			ref:        "mystore/myrepo:main:node_modules/@types/index.d.ts",
+			wantStore:  "mystore",
+			wantRepo:   "myrepo",
+			wantBranch: "main",
+			wantPath:   "node_modules/@types/index.d.ts",
+		},
 		{
 			name:    "missing branch",
 			ref:     "mystore/myrepo",
@@ -197,6 +244,117 @@ func TestParseStoreRepoBranchPath(t *testing.T) {
 	}
 }
 
+func TestParseReferenceAtSeparator(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	tests := []struct {
+		ref  string
+		want model.Reference
+	}{
+		{ref: "s/r@dev", want: model.Reference{Store: "s", Repo: "r", Branch: "dev"}},
+		{ref: "s/r@feature/x:src/a.go", want: model.Reference{Store: "s", Repo: "r", Branch: "feature/x", Path: "src/a.go"}},
+		{ref: "s/r:feature/x:src/a.go", want: model.Reference{Store: "s", Repo: "r", Branch: "feature/x", Path: "src/a.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseReference(tt.ref)
+			if err != nil {
+				t.Fatalf("parseReference(%q) error = %v", tt.ref, err)
+			}
+			if *got != tt.want {
+				t.Errorf("parseReference(%q) = %+v, want %+v", tt.ref, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitCommit(t *testing.T) {
+	tests := []struct {
+		ref        string
+		wantRest   string
+		wantCommit string
+	}{
+		{ref: "s/r", wantRest: "s/r"},
+		{ref: "s/r#abc123", wantRest: "s/r", wantCommit: "abc123"},
+		{ref: "s/r:main#abc123", wantRest: "s/r:main", wantCommit: "abc123"},
+		{ref: "s/r:main#abc123:src/a.go", wantRest: "s/r:main:src/a.go", wantCommit: "abc123"},
+		{ref: "s/r#abc123:src/a.go", wantRest: "s/r::src/a.go", wantCommit: "abc123"},
+		{ref: "s/r@feature/x#abc123:src/a.go", wantRest: "s/r:feature/x:src/a.go", wantCommit: "abc123"},
+		{ref: "s/r:main:docs/#anchor.md", wantRest: "s/r:main:docs/#anchor.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			rest, commit := splitCommit(tt.ref)
+			if rest != tt.wantRest || commit != tt.wantCommit {
+				t.Errorf("splitCommit(%q) = %q, %q, want %q, %q", tt.ref, rest, commit, tt.wantRest, tt.wantCommit)
+			}
+		})
+	}
+}
+
+func TestParseReferenceCommit(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	tests := []struct {
+		ref     string
+		want    model.Reference
+		wantRef string
+	}{
+		{ref: "s/r#abc123", want: model.Reference{Store: "s", Repo: "r", Commit: "abc123"}, wantRef: "abc123"},
+		{ref: "s/r:main#abc123", want: model.Reference{Store: "s", Repo: "r", Branch: "main", Commit: "abc123"}, wantRef: "abc123"},
+		{ref: "s/r#abc123:a.go", want: model.Reference{Store: "s", Repo: "r", Path: "a.go", Commit: "abc123"}, wantRef: "abc123"},
+		{ref: "s/r:main:a.go", want: model.Reference{Store: "s", Repo: "r", Branch: "main", Path: "a.go"}, wantRef: "main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseReference(tt.ref)
+			if err != nil {
+				t.Fatalf("parseReference(%q) error = %v", tt.ref, err)
+			}
+			if *got != tt.want {
+				t.Errorf("parseReference(%q) = %+v, want %+v", tt.ref, *got, tt.want)
+			}
+			if got.Ref() != tt.wantRef {
+				t.Errorf("parseReference(%q).Ref() = %q, want %q", tt.ref, got.Ref(), tt.wantRef)
+			}
+		})
+	}
+}
+
+func TestParseRefsWithDefaultStore(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	if err := config.SetValue("default_store", "home"); err != nil {
+		t.Fatalf("SetValue(default_store) error = %v", err)
+	}
+
+	store, repo, err := parseStoreRepo("myrepo")
+	if err != nil || store != "home" || repo != "myrepo" {
+		t.Errorf("parseStoreRepo(myrepo) = %q, %q, %v, want home, myrepo", store, repo, err)
+	}
+
+	store, repo, err = parseStoreRepo("other/myrepo")
+	if err != nil || store != "other" || repo != "myrepo" {
+		t.Errorf("parseStoreRepo(other/myrepo) = %q, %q, %v, want other, myrepo", store, repo, err)
+	}
+
+	store, repo, branch, err := parseStoreRepoBranch("myrepo:dev")
+	if err != nil || store != "home" || repo != "myrepo" || branch != "dev" {
+		t.Errorf("parseStoreRepoBranch(myrepo:dev) = %q, %q, %q, %v", store, repo, branch, err)
+	}
+
+	store, repo, branch, path, err := parseStoreRepoBranchPath("myrepo:main:README.md")
+	if err != nil || store != "home" || repo != "myrepo" || branch != "main" || path != "README.md" {
+		t.Errorf("parseStoreRepoBranchPath(myrepo:main:README.md) = %q, %q, %q, %q, %v", store, repo, branch, path, err)
+	}
+
+	if _, _, err := parseStoreRepo(""); err == nil {
+		t.Error("parseStoreRepo(\"\") succeeded with a default store")
+	}
+}
+
 func TestFormatStoreRepo(t *testing.T) {
 	got := formatStoreRepo("mystore", "myrepo")
 	want := "mystore/myrepo"
@@ -212,3 +370,28 @@ func TestFormatStoreRepoBranch(t *testing.T) {
 		t.Errorf("formatStoreRepoBranch() = %v, want %v", got, want)
 	}
 }
+
+func TestValidateSlug(t *testing.T) {
+	tests := []struct {
+		slug    string
+		wantErr bool
+	}{
+		{slug: "mystore"},
+		{slug: "my-store_2"},
+		{slug: "0store"},
+		{slug: "", wantErr: true},
+		{slug: "-store", wantErr: true},
+		{slug: "My-Store", wantErr: true},
+		{slug: "my store", wantErr: true},
+		{slug: "my/store", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			err := validateSlug(tt.slug)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSlug(%q) error = %v, wantErr %v", tt.slug, err, tt.wantErr)
+			}
+		})
+	}
+}
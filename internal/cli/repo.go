@@ -2,32 +2,40 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/spf13/cobra"
 
 	"github.com/morrisclay/scraps-cli/internal/api"
-	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/model"
 	"github.com/morrisclay/scraps-cli/internal/tui/components"
 )
 
 func newRepoCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "repo",
-		Short: "Manage repositories",
+		Use:     "repo",
+		Aliases: []string{"repos"},
+		Short:   "Manage repositories",
 	}
 
 	cmd.AddCommand(newRepoListCmd())
 	cmd.AddCommand(newRepoCreateCmd())
 	cmd.AddCommand(newRepoShowCmd())
 	cmd.AddCommand(newRepoDeleteCmd())
+	cmd.AddCommand(newRepoArchiveCmd(true))
+	cmd.AddCommand(newRepoArchiveCmd(false))
 	cmd.AddCommand(newRepoCollaboratorsCmd())
 
 	return cmd
 }
 
 func newRepoListCmd() *cobra.Command {
-	var useTable bool
+	var useTable, noAction bool
+	var fields []string
 
 	cmd := &cobra.Command{
 		Use:   "list [store]",
@@ -35,52 +43,50 @@ func newRepoListCmd() *cobra.Command {
 		Long:  "List repositories. If store is specified, lists repos in that store. Otherwise lists all accessible repos.",
 		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			headers := []string{"REPOSITORY", "CREATED", "ARCHIVED"}
+			if err := validateFields(headers, fields); err != nil {
+				return err
+			}
+
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
 				return err
 			}
 
-			var repos []struct {
-				Store string
-				Name  string
-				ID    string
+			type listedRepo struct {
+				Store     string
+				Name      string
+				ID        string
 				CreatedAt string
+				Archived  bool
 			}
 
-			if len(args) > 0 {
-				// List repos in specific store
-				storeRepos, err := client.ListRepos(args[0])
-				if err != nil {
-					return err
-				}
-				for _, r := range storeRepos {
-					repos = append(repos, struct {
-						Store     string
-						Name      string
-						ID        string
-						CreatedAt string
-					}{args[0], r.Name, r.ID, r.CreatedAt})
-				}
-			} else {
-				// List all repos
-				stores, err := client.ListStores()
-				if err != nil {
-					return err
-				}
-				for _, store := range stores {
-					storeRepos, err := client.ListRepos(store.Slug)
+			repos, err := withLoading("Loading repositories…", func() ([]listedRepo, error) {
+				var repos []listedRepo
+				if len(args) > 0 {
+					// List repos in specific store
+					storeRepos, err := client.ListRepos(args[0])
 					if err != nil {
-						continue
+						return nil, err
 					}
 					for _, r := range storeRepos {
-						repos = append(repos, struct {
-							Store     string
-							Name      string
-							ID        string
-							CreatedAt string
-						}{store.Slug, r.Name, r.ID, r.CreatedAt})
+						repos = append(repos, listedRepo{args[0], r.Name, r.ID, r.CreatedAt, r.Archived})
 					}
+					return repos, nil
 				}
+
+				// List all repos
+				allRepos, err := client.ListAllRepos()
+				if err != nil {
+					return nil, err
+				}
+				for _, r := range allRepos {
+					repos = append(repos, listedRepo{r.Store, r.Name, r.ID, r.CreatedAt, r.Archived})
+				}
+				return repos, nil
+			})
+			if err != nil {
+				return err
 			}
 
 			if len(repos) == 0 {
@@ -88,22 +94,32 @@ func newRepoListCmd() *cobra.Command {
 				return nil
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(repos)
-			} else {
-				headers := []string{"REPOSITORY", "CREATED"}
-				rows := make([][]string, len(repos))
-				for i, r := range repos {
-					rows[i] = []string{formatStoreRepo(r.Store, r.Name), formatDate(r.CreatedAt)}
+			rows := make([][]string, len(repos))
+			for i, r := range repos {
+				archived := "no"
+				if r.Archived {
+					archived = "yes"
+				}
+				rows[i] = []string{formatStoreRepo(r.Store, r.Name), formatTableDate(r.CreatedAt), archived}
+			}
+			if len(fields) > 0 {
+				return outputFields(headers, rows, fields)
+			}
+
+			if isStructuredOutput() && !isDelimitedOutput() {
+				if err := outputStructured(repos); err != nil {
+					return err
 				}
+			} else {
 
 				// Interactive mode - use table or searchable list
-				if isInteractive() {
+				if isInteractive() && !isDelimitedOutput() {
 					if useTable || len(args) > 0 {
 						// Use interactive table for specific store or when flag set
 						columns := []components.TableColumn{
 							{Title: "REPOSITORY", Width: 30},
 							{Title: "CREATED", Width: 15},
+							{Title: "ARCHIVED", Width: 10},
 						}
 						tableRows := make([]table.Row, len(rows))
 						for i, row := range rows {
@@ -114,15 +130,24 @@ func newRepoListCmd() *cobra.Command {
 							return err
 						}
 						if selected != nil {
-							fmt.Printf("\nSelected: %s\n", selected[0])
+							ref := selected[0]
+							if noAction {
+								fmt.Printf("\nSelected: %s\n", ref)
+								return nil
+							}
+							return runActionMenu(ref, repoActions())
 						}
 					} else {
 						// Use searchable list for browsing all repos
 						items := make([]components.SearchListItem, len(repos))
 						for i, r := range repos {
+							desc := fmt.Sprintf("Created: %s", formatDate(r.CreatedAt))
+							if r.Archived {
+								desc += " · archived"
+							}
 							items[i] = components.NewSearchListItem(
 								formatStoreRepo(r.Store, r.Name),
-								fmt.Sprintf("Created: %s", formatDate(r.CreatedAt)),
+								desc,
 								r,
 							)
 						}
@@ -132,28 +157,41 @@ func newRepoListCmd() *cobra.Command {
 							return err
 						}
 						if selected != nil {
-							fmt.Printf("Selected: %s\n", selected.Title())
+							if noAction {
+								fmt.Printf("Selected: %s\n", selected.Title())
+								return nil
+							}
+							return runActionMenu(selected.Title(), repoActions())
 						}
 					}
 					return nil
 				}
 
 				// Non-interactive table output
-				outputTable(headers, rows)
+				return outputTable(headers, rows)
 			}
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&useTable, "table", false, "Use interactive table view instead of list")
+	cmd.Flags().BoolVar(&noAction, "no-action", false, "Print the selected repository instead of offering actions")
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Only show these columns, in this order (e.g. repository)")
 	return cmd
 }
 
 func newRepoCreateCmd() *cobra.Command {
+	var from string
+
 	cmd := &cobra.Command{
-		Use:     "create <store/repo>",
-		Short:   "Create a new repository",
-		Example: "  scraps repo create mystore/myrepo",
+		Use:   "create <store/repo>",
+		Short: "Create a new repository",
+		Long: `Create a new repository.
+
+With --from, an existing directory is wired up to the new repository: it is
+initialized with git if needed, the clone URL is added as a remote and the
+current branch is pushed. The directory must be a git repository or empty.`,
+		Example: "  scraps repo create mystore/myrepo\n  scraps repo create mystore/myrepo --from .",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("repository reference required\n\nUsage: scraps repo create <store/repo>\n\nExample: scraps repo create mystore/myrepo")
@@ -166,6 +204,15 @@ func newRepoCreateCmd() *cobra.Command {
 				return err
 			}
 
+			if from != "" {
+				if err := requireGit(); err != nil {
+					return err
+				}
+				if err := checkPushSource(from); err != nil {
+					return err
+				}
+			}
+
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
 				return err
@@ -176,22 +223,147 @@ func newRepoCreateCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(repo)
-			} else {
+			if !isStructuredOutput() {
 				success(fmt.Sprintf("Repository '%s/%s' created", store, repo.Name))
 			}
+
+			if from != "" {
+				cloneURL := client.GetCloneURL(store, name)
+				remote, pushed, err := pushExistingDir(from, cloneURL)
+				if err != nil {
+					return fmt.Errorf("repository created, but pushing %s failed: %w", from, err)
+				}
+				if !isStructuredOutput() {
+					success(fmt.Sprintf("Added remote '%s': %s", remote, maskSecretIn(cloneURL, client.APIKey())))
+					if pushed != "" {
+						success(fmt.Sprintf("Pushed branch '%s'", pushed))
+					} else {
+						info(fmt.Sprintf("No commits to push yet; commit and run: git push -u %s HEAD", remote))
+					}
+				}
+			}
+
+			if isStructuredOutput() {
+				return outputStructured(repo)
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Push an existing directory to the new repository")
 	return cmd
 }
 
+// checkPushSource verifies that dir is a git repository or an empty
+// directory, so repo create --from never pushes an unrelated tree.
+func checkPushSource(dir string) error {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	if _, err := runGit(dir, "rev-parse", "--git-dir"); err == nil {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s is neither a git repository nor empty\n\nRun 'git init' and commit your files first", dir)
+	}
+	return nil
+}
+
+// pushExistingDir initializes dir as a git repository if needed, adds
+// cloneURL as a remote and pushes the current branch. It returns the remote
+// name and the pushed branch, which is empty when there are no commits yet.
+func pushExistingDir(dir, cloneURL string) (string, string, error) {
+	if _, err := runGit(dir, "rev-parse", "--git-dir"); err != nil {
+		if _, err := runGit(dir, "init"); err != nil {
+			return "", "", err
+		}
+	}
+
+	// Keep an existing origin intact
+	remote := "origin"
+	if _, err := runGit(dir, "remote", "get-url", remote); err == nil {
+		remote = "scraps"
+	}
+	if _, err := runGit(dir, "remote", "add", remote, cloneURL); err != nil {
+		return "", "", err
+	}
+
+	if _, err := runGit(dir, "rev-parse", "--verify", "HEAD"); err != nil {
+		return remote, "", nil
+	}
+	branch, err := runGit(dir, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", "", fmt.Errorf("cannot push a detached HEAD; check out a branch first")
+	}
+
+	push := exec.Command("git", "push", "-u", remote, branch)
+	push.Dir = dir
+	push.Stdout = os.Stderr
+	push.Stderr = os.Stderr
+	if err := push.Run(); err != nil {
+		return "", "", fmt.Errorf("git push failed: %w", err)
+	}
+	return remote, branch, nil
+}
+
+// runGit runs a git command in dir and returns its trimmed output.
+func runGit(dir string, args ...string) (string, error) {
+	gitCmd := exec.Command("git", args...)
+	gitCmd.Dir = dir
+	out, err := gitCmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// repoStats is the extra detail shown by repo show --stats. Fields are nil
+// when the corresponding request failed or, for LatestCommit, when the
+// repository has no commits yet.
+type repoStats struct {
+	*model.Repository
+	Branches     *int          `json:"branches"`
+	LatestCommit *model.Commit `json:"latest_commit"`
+}
+
+// fetchRepoStats gathers branch and latest-commit details for repo. Errors
+// are tolerated so an empty repository still shows its basic details.
+func fetchRepoStats(client *api.Client, store string, repo *model.Repository) repoStats {
+	stats := repoStats{Repository: repo}
+
+	if branches, err := client.ListBranches(store, repo.Name); err == nil {
+		n := len(branches)
+		stats.Branches = &n
+	}
+
+	branch := repo.DefaultBranch
+	if branch == "" {
+		branch = "main"
+	}
+	if commits, err := client.GetLog(store, repo.Name, branch, 1); err == nil && len(commits) > 0 {
+		stats.LatestCommit = &commits[0]
+	}
+	return stats
+}
+
 func newRepoShowCmd() *cobra.Command {
+	var withStats bool
+
 	cmd := &cobra.Command{
 		Use:     "show <store/repo>",
 		Short:   "Show repository details",
-		Example: "  scraps repo show mystore/myrepo",
+		Example: "  scraps repo show mystore/myrepo\n  scraps repo show mystore/myrepo --stats",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("repository reference required\n\nUsage: scraps repo show <store/repo>\n\nExample: scraps repo show mystore/myrepo")
@@ -214,15 +386,96 @@ func newRepoShowCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(repo)
+			var stats repoStats
+			if withStats {
+				stats = fetchRepoStats(client, store, repo)
+			}
+
+			if isStructuredOutput() {
+				var data any = repo
+				if withStats {
+					data = stats
+				}
+				if err := outputStructured(data); err != nil {
+					return err
+				}
 			} else {
 				fmt.Printf("Name:           %s\n", repo.Name)
 				fmt.Printf("Store:          %s\n", store)
 				fmt.Printf("ID:             %s\n", repo.ID)
 				fmt.Printf("Default Branch: %s\n", repo.DefaultBranch)
 				fmt.Printf("Created:        %s\n", formatDateTime(repo.CreatedAt))
+				if repo.Archived {
+					fmt.Printf("Archived:       yes (read-only)\n")
+				} else {
+					fmt.Printf("Archived:       no\n")
+				}
+				if withStats {
+					branches := "unknown"
+					if stats.Branches != nil {
+						branches = strconv.Itoa(*stats.Branches)
+					}
+					fmt.Printf("Branches:       %s\n", branches)
+					if c := stats.LatestCommit; c != nil {
+						fmt.Printf("Last Commit:    %s %s\n", shortSHA(commitSHA(*c)), commitSubject(*c))
+						if c.Date != "" {
+							fmt.Printf("Last Activity:  %s\n", formatDateTime(c.Date))
+						}
+					} else {
+						fmt.Printf("Last Commit:    none\n")
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&withStats, "stats", false, "Include branch count and latest commit (extra requests)")
+	return cmd
+}
+
+// newRepoArchiveCmd returns the archive command, or unarchive when archived
+// is false.
+func newRepoArchiveCmd(archived bool) *cobra.Command {
+	use, short, done := "archive", "Archive a repository, making it read-only", "archived"
+	if !archived {
+		use, short, done = "unarchive", "Unarchive a repository, making it writable again", "unarchived"
+	}
+
+	cmd := &cobra.Command{
+		Use:     use + " <store/repo>",
+		Short:   short,
+		Example: fmt.Sprintf("  scraps repo %s mystore/myrepo", use),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("repository reference required\n\nUsage: scraps repo %s <store/repo>\n\nExample: scraps repo %s mystore/myrepo", use, use)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, name, err := parseStoreRepo(args[0])
+			if err != nil {
+				return err
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			if err := client.SetRepoArchived(store, name, archived); err != nil {
+				return err
+			}
+
+			if isStructuredOutput() {
+				return outputStructured(map[string]any{
+					"store":    store,
+					"repo":     name,
+					"archived": archived,
+				})
 			}
+
+			success(fmt.Sprintf("Repository '%s' %s", formatStoreRepo(store, name), done))
 			return nil
 		},
 	}
@@ -234,6 +487,7 @@ func newRepoDeleteCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:     "delete <store/repo>",
+		Aliases: []string{"rm"},
 		Short:   "Delete a repository",
 		Example: "  scraps repo delete mystore/myrepo",
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -250,10 +504,10 @@ func newRepoDeleteCmd() *cobra.Command {
 
 			// Confirm deletion
 			if !force && isInteractive() {
-				confirmed, err := components.RunConfirm(
+				confirmed, err := confirmDeletion(
 					"Delete Repository",
 					fmt.Sprintf("Are you sure you want to delete '%s/%s'?\nThis cannot be undone.", store, name),
-					true,
+					formatStoreRepo(store, name),
 				)
 				if err != nil {
 					return err
@@ -292,12 +546,15 @@ func newRepoCollaboratorsCmd() *cobra.Command {
 
 	cmd.AddCommand(newRepoCollaboratorsListCmd())
 	cmd.AddCommand(newRepoCollaboratorsAddCmd())
+	cmd.AddCommand(newRepoCollaboratorsUpdateCmd())
 	cmd.AddCommand(newRepoCollaboratorsRemoveCmd())
 
 	return cmd
 }
 
 func newRepoCollaboratorsListCmd() *cobra.Command {
+	var fields []string
+
 	cmd := &cobra.Command{
 		Use:     "list <store/repo>",
 		Short:   "List collaborators of a repository",
@@ -309,6 +566,11 @@ func newRepoCollaboratorsListCmd() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			headers := []string{"USERNAME", "ROLE", "ADDED"}
+			if err := validateFields(headers, fields); err != nil {
+				return err
+			}
+
 			store, name, err := parseStoreRepo(args[0])
 			if err != nil {
 				return err
@@ -329,14 +591,19 @@ func newRepoCollaboratorsListCmd() *cobra.Command {
 				return nil
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(collabs)
-			} else {
-				headers := []string{"USERNAME", "ROLE", "ADDED"}
-				rows := make([][]string, len(collabs))
-				for i, c := range collabs {
-					rows[i] = []string{c.Username, c.Role, formatDate(c.CreatedAt)}
+			rows := make([][]string, len(collabs))
+			for i, c := range collabs {
+				rows[i] = []string{c.Username, c.Role, formatTableDate(c.CreatedAt)}
+			}
+			if len(fields) > 0 {
+				return outputFields(headers, rows, fields)
+			}
+
+			if isStructuredOutput() && !isDelimitedOutput() {
+				if err := outputStructured(collabs); err != nil {
+					return err
 				}
+			} else {
 
 				// Use interactive table if available
 				if isInteractive() {
@@ -347,13 +614,14 @@ func newRepoCollaboratorsListCmd() *cobra.Command {
 					if selected != nil {
 						fmt.Printf("\nSelected: %s (%s)\n", selected[0], selected[1])
 					}
-				} else {
-					outputTable(headers, rows)
+				} else if err := outputTable(headers, rows); err != nil {
+					return err
 				}
 			}
 			return nil
 		},
 	}
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Only show these columns, in this order (e.g. username,role)")
 	return cmd
 }
 
@@ -380,6 +648,9 @@ func newRepoCollaboratorsAddCmd() *cobra.Command {
 			if role == "" {
 				role = "read"
 			}
+			if err := validateRole(role, repoRoles); err != nil {
+				return err
+			}
 
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
@@ -391,8 +662,10 @@ func newRepoCollaboratorsAddCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(collab)
+			if isStructuredOutput() {
+				if err := outputStructured(collab); err != nil {
+					return err
+				}
 			} else {
 				success(fmt.Sprintf("Added %s to %s/%s with role %s", username, store, name, collab.Role))
 			}
@@ -404,11 +677,80 @@ func newRepoCollaboratorsAddCmd() *cobra.Command {
 	return cmd
 }
 
+func newRepoCollaboratorsUpdateCmd() *cobra.Command {
+	var role string
+
+	cmd := &cobra.Command{
+		Use:     "update <store/repo> <username>",
+		Short:   "Update a collaborator's role",
+		Example: "  scraps repo collaborators update mystore/myrepo johndoe --role write",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return fmt.Errorf("repository and username required\n\nUsage: scraps repo collaborators update <store/repo> <username> --role <role>\n\nExample: scraps repo collaborators update mystore/myrepo johndoe --role write")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, name, err := parseStoreRepo(args[0])
+			if err != nil {
+				return err
+			}
+			username := args[1]
+
+			if err := validateRole(role, repoRoles); err != nil {
+				return err
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			// Find collaborator
+			collabs, err := client.ListCollaborators(store, name)
+			if err != nil {
+				return err
+			}
+
+			var collab *model.Collaborator
+			for i := range collabs {
+				if collabs[i].Username == username {
+					collab = &collabs[i]
+					break
+				}
+			}
+
+			if collab == nil {
+				return fmt.Errorf("'%s' is not a collaborator on '%s/%s'", username, store, name)
+			}
+
+			if err := client.UpdateCollaborator(store, name, collab.ID, role); err != nil {
+				return err
+			}
+			collab.Role = role
+
+			if isStructuredOutput() {
+				if err := outputStructured(collab); err != nil {
+					return err
+				}
+			} else {
+				success(fmt.Sprintf("Updated %s's role on %s/%s to %s", username, store, name, role))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&role, "role", "r", "", "New role (read, write, admin)")
+	cmd.MarkFlagRequired("role")
+	return cmd
+}
+
 func newRepoCollaboratorsRemoveCmd() *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
 		Use:     "remove <store/repo> <username>",
+		Aliases: []string{"rm"},
 		Short:   "Remove a collaborator from a repository",
 		Example: "  scraps repo collaborators remove mystore/myrepo johndoe",
 		Args: func(cmd *cobra.Command, args []string) error {
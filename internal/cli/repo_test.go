@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestCheckPushSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	empty := t.TempDir()
+
+	nonEmpty := t.TempDir()
+	if err := os.WriteFile(filepath.Join(nonEmpty, "README"), []byte("hi"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gitDir := t.TempDir()
+	if _, err := runGit(gitDir, "init"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "README"), []byte("hi"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		wantErr bool
+	}{
+		{"empty directory", empty, false},
+		{"git repository", gitDir, false},
+		{"non-empty directory", nonEmpty, true},
+		{"missing directory", filepath.Join(empty, "missing"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPushSource(tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkPushSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPushExistingDir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	remoteDir := t.TempDir()
+	if _, err := runGit(remoteDir, "init", "--bare"); err != nil {
+		t.Fatal(err)
+	}
+
+	src := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"checkout", "-b", "main"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial"},
+	} {
+		if _, err := runGit(src, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	remote, branch, err := pushExistingDir(src, remoteDir)
+	if err != nil {
+		t.Fatalf("pushExistingDir() error = %v", err)
+	}
+	if remote != "origin" || branch != "main" {
+		t.Errorf("pushExistingDir() = %q, %q, want origin, main", remote, branch)
+	}
+	if _, err := runGit(remoteDir, "rev-parse", "--verify", "main"); err != nil {
+		t.Errorf("branch was not pushed: %v", err)
+	}
+}
+
+func TestFetchRepoStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		logStatus  int
+		wantCommit bool
+	}{
+		{name: "with commits", logStatus: http.StatusOK, wantCommit: true},
+		{name: "empty repo", logStatus: http.StatusNotFound, wantCommit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/branches"):
+					json.NewEncoder(w).Encode([]map[string]string{{"name": "main"}, {"name": "dev"}})
+				case strings.Contains(r.URL.Path, "/log/dev"):
+					if tt.logStatus != http.StatusOK {
+						w.WriteHeader(tt.logStatus)
+						w.Write([]byte(`{"error": "no commits"}`))
+						return
+					}
+					json.NewEncoder(w).Encode([]model.Commit{{SHA: "abc1234def", Message: "Initial"}})
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+			client := api.NewClient(server.URL, "test-key")
+			stats := fetchRepoStats(client, "s", &model.Repository{Name: "r", DefaultBranch: "dev"})
+
+			if stats.Branches == nil || *stats.Branches != 2 {
+				t.Errorf("Branches = %v, want 2", stats.Branches)
+			}
+			if (stats.LatestCommit != nil) != tt.wantCommit {
+				t.Errorf("LatestCommit = %v, want present=%v", stats.LatestCommit, tt.wantCommit)
+			}
+		})
+	}
+}
+
+func TestRepoListArchived(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]model.Repository{
+			{Name: "api", CreatedAt: "2024-03-15T10:30:00Z"},
+			{Name: "legacy", CreatedAt: "2023-01-01T00:00:00Z", Archived: true},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "csv")
+
+	// The archived state is its own column, never part of the repo name
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"acme"}, want: "REPOSITORY,CREATED,ARCHIVED\nacme/api,\"Mar 15, 2024\",no\nacme/legacy,\"Jan 01, 2023\",yes\n"},
+		{args: []string{"acme", "--fields", "repository"}, want: "REPOSITORY\nacme/api\nacme/legacy\n"},
+	}
+
+	for _, tt := range tests {
+		cmd := newRepoListCmd()
+		cmd.SetArgs(tt.args)
+		var err error
+		out := captureStdout(t, func() { err = cmd.Execute() })
+		if err != nil {
+			t.Fatalf("repo list %v error = %v", tt.args, err)
+		}
+		if out != tt.want {
+			t.Errorf("repo list %v output = %q, want %q", tt.args, out, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Roles accepted by the server for store members and repo collaborators.
+var (
+	storeRoles = []string{"admin", "member", "read"}
+	repoRoles  = []string{"admin", "write", "read"}
+)
+
+// validateRole checks role against the allowed set so obvious typos fail
+// before any API call. The server remains the source of truth.
+func validateRole(role string, allowed []string) error {
+	for _, r := range allowed {
+		if role == r {
+			return nil
+		}
+	}
+
+	msg := fmt.Sprintf("invalid role %q (valid roles: %s)", role, strings.Join(allowed, ", "))
+	if s := closestMatch(role, allowed); s != "" {
+		msg += fmt.Sprintf("\n\nDid you mean '%s'?", s)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// closestMatch returns the candidate nearest to s by edit distance, or ""
+// if none is close enough to be a plausible typo.
+func closestMatch(s string, candidates []string) string {
+	s = strings.ToLower(s)
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(s, c)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	if bestDist < 0 || bestDist > len(best)/2 {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
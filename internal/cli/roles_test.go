@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		role    string
+		allowed []string
+		wantErr bool
+		suggest string
+	}{
+		{name: "valid repo role", role: "write", allowed: repoRoles},
+		{name: "valid store role", role: "member", allowed: storeRoles},
+		{name: "transposed letters", role: "wirte", allowed: repoRoles, wantErr: true, suggest: "write"},
+		{name: "wrong case", role: "Admin", allowed: storeRoles, wantErr: true, suggest: "admin"},
+		{name: "store role on repo", role: "member", allowed: repoRoles, wantErr: true},
+		{name: "no close match", role: "owner", allowed: storeRoles, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRole(tt.role, tt.allowed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateRole(%q) error = %v, wantErr %v", tt.role, err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			hasSuggestion := strings.Contains(err.Error(), "Did you mean")
+			if tt.suggest == "" && hasSuggestion {
+				t.Errorf("validateRole(%q) = %q, want no suggestion", tt.role, err)
+			}
+			if tt.suggest != "" && !strings.Contains(err.Error(), "'"+tt.suggest+"'") {
+				t.Errorf("validateRole(%q) = %q, want suggestion %q", tt.role, err, tt.suggest)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"read", "read", 0},
+		{"wirte", "write", 2},
+		{"admn", "admin", 1},
+		{"", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
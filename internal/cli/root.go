@@ -2,56 +2,117 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
 
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/log"
 	"github.com/morrisclay/scraps-cli/pkg/version"
 )
 
-// versionWithCheck returns version info and checks for updates.
-func versionWithCheck() string {
-	result := "scraps version " + version.Version
+var outputFormat string
 
-	latest, err := version.CheckLatest()
-	if err != nil {
-		return result
-	}
+// revealSecrets disables mask_secrets for a single invocation.
+var revealSecrets bool
 
-	if version.IsOutdated(version.Version, latest) {
-		result += fmt.Sprintf("\n\n! Update available: %s → %s", version.Version, latest)
-		result += "\n  Run: curl -fsSL https://scraps.sh/install.sh | sh"
-	}
+// relativeDates is the global --relative flag, which shows table dates
+// relative to now like the relative_dates config option.
+var relativeDates bool
 
-	return result
-}
+// verbose is the global --verbose flag, which traces HTTP requests.
+var verbose bool
 
-var outputFormat string
+// quiet is the global --quiet flag, which silences info, success and
+// warning messages and spinners. Results and errors are still printed.
+var quiet bool
+
+// logFile is the global --log-file flag.
+var logFile string
+
+// hostOverride is the global --host flag.
+var hostOverride string
 
 var rootCmd = &cobra.Command{
 	Use:   "scraps",
 	Short: "Scraps CLI - Git-native context sharing for AI agents",
 	Long: `Scraps is a Git-native context sharing system for AI agents.
 It provides stores, repositories, and coordination primitives
-for multi-agent collaboration.`,
+for multi-agent collaboration.
+
+Exit codes:
+  0  success
+  1  any other error
+  2  not logged in, or not authorized (HTTP 401/403)
+  3  not found (HTTP 404)
+  4  conflict (HTTP 409, or patterns claimed by another agent)
+  5  network error reaching the API`,
 	Version:      version.Version,
 	SilenceUsage: true,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	// Execute reports errors itself, as JSON in structured output mode
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// Override output format if flag is set
 		if outputFormat != "" {
 			os.Setenv("SCRAPS_OUTPUT_FORMAT", outputFormat)
 		}
+		// Point API clients at another host without touching config.json
+		if hostOverride != "" {
+			host, err := config.NormalizeHost(hostOverride)
+			if err != nil {
+				return err
+			}
+			api.HostOverride = host
+		}
+		if verbose {
+			api.Trace = os.Stderr
+		}
+		if err := setupLog(); err != nil {
+			return err
+		}
+		log.Debug("command started", "command", cmd.CommandPath(), "version", version.Version)
+		// lipgloss only checks for a terminal, so honor the flag and NO_COLOR here too
+		if noColor || os.Getenv("NO_COLOR") != "" {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+		return nil
 	},
 }
 
-
 // Execute runs the CLI.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		log.Error("command failed", "error", err, "exit_code", exitCode(err))
+		reportError(err)
+		os.Exit(exitCode(err))
 	}
+	notifyUpdate()
+}
+
+// setupLog starts the diagnostic log when --log-file or SCRAPS_LOG names a
+// file. SCRAPS_LOG_LEVEL limits it to info, warn or error; the default is
+// everything. The file is left open until the process exits.
+func setupLog() error {
+	path := logFile
+	if path == "" {
+		path = os.Getenv("SCRAPS_LOG")
+	}
+	if path == "" {
+		return nil
+	}
+	level, err := log.ParseLevel(os.Getenv("SCRAPS_LOG_LEVEL"))
+	if err != nil {
+		return err
+	}
+	_, err = log.Open(path, level)
+	return err
 }
 
 // Command group IDs
@@ -65,7 +126,16 @@ const (
 
 func init() {
 	// Global flags
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (table, json, yaml, csv, tsv)")
+	rootCmd.PersistentFlags().StringVarP(&hostOverride, "host", "H", "", "API host to use instead of the configured default")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log HTTP requests to stderr")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only print results and errors")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append a diagnostic log to this file (or set SCRAPS_LOG)")
+	rootCmd.PersistentFlags().BoolVar(&api.NoCache, "no-cache", false, "Always fetch store and repo listings from the server")
+	rootCmd.PersistentFlags().BoolVar(&noTruncate, "no-truncate", false, "Show table cells in full instead of fitting them to the terminal width")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&relativeDates, "relative", false, "Show table dates relative to now, e.g. \"3 days ago\" (or set relative_dates)")
+	rootCmd.PersistentFlags().BoolVar(&revealSecrets, "reveal", false, "Show API keys in full even when mask_secrets is on")
 
 	// Disable default completion command
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
@@ -89,15 +159,19 @@ func init() {
 	rootCmd.AddCommand(withGroup(newLogoutCmd(), groupAuth))
 	rootCmd.AddCommand(withGroup(newWhoamiCmd(), groupAuth))
 	rootCmd.AddCommand(withGroup(newStatusCmd(), groupAuth))
+	rootCmd.AddCommand(withGroup(newPingCmd(), groupAuth))
 
 	// Data management commands
 	rootCmd.AddCommand(withGroup(newStoreCmd(), groupData))
 	rootCmd.AddCommand(withGroup(newRepoCmd(), groupData))
 	rootCmd.AddCommand(withGroup(newFileCmd(), groupData))
+	rootCmd.AddCommand(withGroup(newBrowseCmd(), groupData))
+	rootCmd.AddCommand(withGroup(newExportCmd(), groupData))
 
 	// Workflow commands
 	rootCmd.AddCommand(withGroup(newCloneCmd(), groupWorkflow))
 	rootCmd.AddCommand(withGroup(newLogCmd(), groupWorkflow))
+	rootCmd.AddCommand(withGroup(newShowCmd(), groupWorkflow))
 	rootCmd.AddCommand(withGroup(newWatchCmd(), groupWorkflow))
 
 	// Coordination commands
@@ -108,6 +182,7 @@ func init() {
 	rootCmd.AddCommand(withGroup(newConfigCmd(), groupSettings))
 	rootCmd.AddCommand(withGroup(newKeyCmd(), groupSettings))
 	rootCmd.AddCommand(withGroup(newTokenCmd(), groupSettings))
+	rootCmd.AddCommand(withGroup(newVersionCmd(), groupSettings))
 
 	// Register custom template functions and set usage template
 	cobra.AddTemplateFunc("commandsByGroupOrdered", func(cmds []*cobra.Command, groupID string) []*cobra.Command {
@@ -179,7 +254,10 @@ func withGroup(cmd *cobra.Command, groupID string) *cobra.Command {
 // helper functions for output
 
 func success(msg string) {
-	fmt.Printf("✓ %s\n", msg)
+	if quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "✓ %s\n", msg)
 }
 
 func errorf(format string, args ...any) {
@@ -187,9 +265,37 @@ func errorf(format string, args ...any) {
 }
 
 func warn(msg string) {
-	fmt.Printf("! %s\n", msg)
+	if quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "! %s\n", msg)
 }
 
 func info(msg string) {
-	fmt.Printf("→ %s\n", msg)
+	if quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "→ %s\n", msg)
+}
+
+// isForbidden returns true if err is an API 403 error.
+func isForbidden(err error) bool {
+	var apiErr *api.APIError
+	return errors.As(err, &apiErr) && apiErr.IsForbidden()
+}
+
+// isNotFound returns true if err is an API 404 error.
+func isNotFound(err error) bool {
+	var apiErr *api.APIError
+	return errors.As(err, &apiErr) && apiErr.IsNotFound()
+}
+
+// rateLimitRetryAfter reports whether err is an API 429 error and, if so,
+// how long the server asked us to wait (zero if it did not say).
+func rateLimitRetryAfter(err error) (time.Duration, bool) {
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) && apiErr.IsRateLimited() {
+		return apiErr.RetryAfter, true
+	}
+	return 0, false
 }
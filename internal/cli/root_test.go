@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandAliases(t *testing.T) {
+	tests := []struct {
+		args       []string
+		wantName   string
+		wantParent string
+	}{
+		{args: []string{"stores", "list"}, wantName: "list", wantParent: "store"},
+		{args: []string{"repos", "list"}, wantName: "list", wantParent: "repo"},
+		{args: []string{"store", "rm"}, wantName: "delete", wantParent: "store"},
+		{args: []string{"repo", "rm"}, wantName: "delete", wantParent: "repo"},
+		{args: []string{"file", "rm"}, wantName: "delete", wantParent: "file"},
+		{args: []string{"claims", "watch"}, wantName: "watch", wantParent: "claim"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cmd, _, err := rootCmd.Find(tt.args)
+			if err != nil {
+				t.Fatalf("Find(%v) error = %v", tt.args, err)
+			}
+			if cmd.Name() != tt.wantName {
+				t.Errorf("Name() = %v, want %v", cmd.Name(), tt.wantName)
+			}
+			if cmd.Parent().Name() != tt.wantParent {
+				t.Errorf("Parent().Name() = %v, want %v", cmd.Parent().Name(), tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestUsageListsAliasedCommandsOnce(t *testing.T) {
+	usage := rootCmd.UsageString()
+
+	for _, name := range []string{"store", "repo"} {
+		if n := strings.Count(usage, "\n  "+name+" "); n != 1 {
+			t.Errorf("usage lists %q %d times, want 1", name, n)
+		}
+	}
+	for _, alias := range []string{"stores", "repos"} {
+		if strings.Contains(usage, "\n  "+alias+" ") {
+			t.Errorf("usage lists alias %q as a separate command", alias)
+		}
+	}
+}
+
+func TestQuietSuppressesMessages(t *testing.T) {
+	defer func() { quiet = false }()
+
+	emit := func() {
+		success("created")
+		warn("careful")
+		info("working")
+	}
+
+	if out := captureStderr(t, emit); !strings.Contains(out, "created") || !strings.Contains(out, "careful") || !strings.Contains(out, "working") {
+		t.Errorf("without --quiet output = %q, want all messages", out)
+	}
+
+	quiet = true
+	if out := captureStderr(t, emit); out != "" {
+		t.Errorf("with --quiet output = %q, want nothing", out)
+	}
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func newShowCmd() *cobra.Command {
+	var patch bool
+
+	cmd := &cobra.Command{
+		Use:   "show <store/repo:branch:sha>",
+		Short: "Show a commit and the files it changed",
+		Long: `Show a commit and the files it changed.
+
+Commits are looked up by sha across the whole repository, so the branch in
+store/repo:branch:sha is not checked against the commit. It may be left out
+with store/repo:sha.`,
+		Example: `  scraps show mystore/myrepo:main:abc1234
+  scraps show mystore/myrepo:abc1234
+  scraps show mystore/myrepo:main:abc1234 --patch`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("commit reference required\n\nUsage: scraps show <store/repo:branch:sha>\n\nExample: scraps show mystore/myrepo:main:abc1234")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, repo, branch, sha, err := parseStoreRepoBranchPath(args[0])
+			if err != nil {
+				return err
+			}
+
+			// Allow store/repo:sha when the branch is omitted
+			if sha == "" {
+				sha = branch
+			}
+			if sha == "" {
+				return fmt.Errorf("commit sha is required")
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			commit, err := client.GetCommit(store, repo, sha)
+			if err != nil {
+				if isNotFound(err) {
+					return fmt.Errorf("commit '%s' not found in %s: %w", sha, formatStoreRepo(store, repo), err)
+				}
+				return err
+			}
+
+			if isStructuredOutput() {
+				return outputStructured(commit)
+			}
+
+			out := formatCommitDetail(commit, patch)
+
+			// Long patches are easier to read in a scrollable viewer
+			if patch && isInteractive() && strings.Count(out, "\n") > 40 {
+				return runFileViewer(out, shortSHA(commit.SHA)+" "+firstLine(commit.Message))
+			}
+
+			fmt.Print(out)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&patch, "patch", false, "Include full diffs for each file")
+	return cmd
+}
+
+// formatCommitDetail renders commit metadata followed by a +/-/~ file
+// summary and, if requested, each file's patch.
+func formatCommitDetail(c *model.CommitDetail, patch bool) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n", colorize(colorYellow, "commit "+c.SHA))
+	author := c.Author.Name
+	if author == "" {
+		author = c.Author.Raw
+	}
+	if author != "" {
+		if c.Author.Email != "" {
+			author += " <" + c.Author.Email + ">"
+		}
+		fmt.Fprintf(&b, "Author: %s\n", author)
+	}
+	if c.Date != "" {
+		fmt.Fprintf(&b, "Date:   %s\n", formatDateTime(c.Date))
+	}
+	if len(c.Parents) > 0 {
+		parents := make([]string, len(c.Parents))
+		for i, p := range c.Parents {
+			parents[i] = shortSHA(p)
+		}
+		fmt.Fprintf(&b, "Parent: %s\n", strings.Join(parents, " "))
+	}
+
+	b.WriteString("\n")
+	for _, line := range strings.Split(strings.TrimRight(c.Message, "\n"), "\n") {
+		fmt.Fprintf(&b, "    %s\n", line)
+	}
+
+	if len(c.Files) > 0 {
+		b.WriteString("\n")
+		for _, f := range c.Files {
+			fmt.Fprintf(&b, "  %s %s\n", fileActionSymbol(f.Action), f.Path)
+		}
+	}
+
+	if patch {
+		for _, f := range c.Files {
+			if f.Patch == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "\n%s\n", colorize(colorCyan, "--- "+f.Path))
+			b.WriteString(strings.TrimRight(f.Patch, "\n"))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// fileActionSymbol returns a colored +/-/~ marker for a file change action.
+func fileActionSymbol(action string) string {
+	switch action {
+	case "add", "added", "create":
+		return colorize(colorGreen, "+")
+	case "delete", "deleted", "remove":
+		return colorize(colorRed, "-")
+	default:
+		return colorize(colorYellow, "~")
+	}
+}
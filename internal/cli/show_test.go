@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestFormatCommitDetail(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	commit := &model.CommitDetail{
+		SHA:     "abc1234def5678",
+		Message: "Add parser\n\nHandles nested blocks.\n",
+		Author:  model.CommitAuthor{Name: "Ada", Email: "ada@example.com"},
+		Date:    "2024-03-15T10:30:00Z",
+		Parents: []string{"0123456789abcdef"},
+		Files: []model.FileChange{
+			{Action: "added", Path: "parser.go", Patch: "+package parser\n"},
+			{Action: "delete", Path: "old.go"},
+			{Action: "modify", Path: "main.go", Patch: "-a\n+b"},
+		},
+	}
+
+	header := "commit abc1234def5678\n" +
+		"Author: Ada <ada@example.com>\n" +
+		"Date:   Mar 15, 2024 10:30\n" +
+		"Parent: 0123456\n" +
+		"\n" +
+		"    Add parser\n" +
+		"    \n" +
+		"    Handles nested blocks.\n" +
+		"\n" +
+		"  + parser.go\n" +
+		"  - old.go\n" +
+		"  ~ main.go\n"
+
+	tests := []struct {
+		name   string
+		commit *model.CommitDetail
+		patch  bool
+		want   string
+	}{
+		{name: "summary", commit: commit, want: header},
+		{
+			name:   "with patches",
+			commit: commit,
+			patch:  true,
+			want:   header + "\n--- parser.go\n+package parser\n" + "\n--- main.go\n-a\n+b\n",
+		},
+		{
+			name:   "plain author and no files",
+			commit: &model.CommitDetail{SHA: "abc", Message: "Init", Author: model.CommitAuthor{Raw: "Ada <ada@example.com>"}},
+			want:   "commit abc\nAuthor: Ada <ada@example.com>\n\n    Init\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatCommitDetail(tt.commit, tt.patch); got != tt.want {
+				t.Errorf("formatCommitDetail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,25 +1,34 @@
 package cli
 
 import (
+	"encoding/csv"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/spf13/cobra"
 
 	"github.com/morrisclay/scraps-cli/internal/api"
 	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/model"
 	"github.com/morrisclay/scraps-cli/internal/tui/components"
 )
 
 func newStoreCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "store",
-		Short: "Manage stores",
+		Use:     "store",
+		Aliases: []string{"stores"},
+		Short:   "Manage stores",
 	}
 
 	cmd.AddCommand(newStoreListCmd())
 	cmd.AddCommand(newStoreCreateCmd())
 	cmd.AddCommand(newStoreShowCmd())
+	cmd.AddCommand(newStoreRenameCmd())
+	cmd.AddCommand(newStoreTransferCmd())
 	cmd.AddCommand(newStoreDeleteCmd())
 	cmd.AddCommand(newStoreMembersCmd())
 
@@ -27,18 +36,24 @@ func newStoreCmd() *cobra.Command {
 }
 
 func newStoreListCmd() *cobra.Command {
-	var useTable bool
+	var useTable, noAction bool
+	var fields []string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List stores you are a member of",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			headers := []string{"SLUG", "ROLE", "CREATED"}
+			if err := validateFields(headers, fields); err != nil {
+				return err
+			}
+
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
 				return err
 			}
 
-			stores, err := client.ListStores()
+			stores, err := withLoading("Loading stores…", client.ListStores)
 			if err != nil {
 				return err
 			}
@@ -48,17 +63,22 @@ func newStoreListCmd() *cobra.Command {
 				return nil
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(stores)
-			} else {
-				headers := []string{"SLUG", "ROLE", "CREATED"}
-				rows := make([][]string, len(stores))
-				for i, s := range stores {
-					rows[i] = []string{s.Slug, s.Role, formatDate(s.CreatedAt)}
+			rows := make([][]string, len(stores))
+			for i, s := range stores {
+				rows[i] = []string{s.Slug, s.Role, formatTableDate(s.CreatedAt)}
+			}
+			if len(fields) > 0 {
+				return outputFields(headers, rows, fields)
+			}
+
+			if isStructuredOutput() && !isDelimitedOutput() {
+				if err := outputStructured(stores); err != nil {
+					return err
 				}
+			} else {
 
 				// Interactive mode - use table or searchable list
-				if isInteractive() {
+				if isInteractive() && !isDelimitedOutput() {
 					if useTable {
 						// Use interactive table
 						columns := []components.TableColumn{
@@ -75,7 +95,11 @@ func newStoreListCmd() *cobra.Command {
 							return err
 						}
 						if selected != nil {
-							fmt.Printf("\nSelected: %s\n", selected[0])
+							if noAction {
+								fmt.Printf("\nSelected: %s\n", selected[0])
+								return nil
+							}
+							return runActionMenu(selected[0], storeActions())
 						}
 					} else {
 						// Use searchable list
@@ -93,20 +117,26 @@ func newStoreListCmd() *cobra.Command {
 							return err
 						}
 						if selected != nil {
-							fmt.Printf("Selected: %s\n", selected.Title())
+							if noAction {
+								fmt.Printf("Selected: %s\n", selected.Title())
+								return nil
+							}
+							return runActionMenu(selected.Title(), storeActions())
 						}
 					}
 					return nil
 				}
 
 				// Non-interactive table output
-				outputTable(headers, rows)
+				return outputTable(headers, rows)
 			}
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&useTable, "table", false, "Use interactive table view instead of list")
+	cmd.Flags().BoolVar(&noAction, "no-action", false, "Print the selected store instead of offering actions")
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Only show these columns, in this order (e.g. slug,role)")
 	return cmd
 }
 
@@ -127,8 +157,8 @@ func newStoreCreateCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() != "json" {
-				fmt.Printf("Creating store '%s'...\n", args[0])
+			if !isStructuredOutput() {
+				info(fmt.Sprintf("Creating store '%s'...", args[0]))
 			}
 
 			store, err := client.CreateStore(args[0])
@@ -136,8 +166,10 @@ func newStoreCreateCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(store)
+			if isStructuredOutput() {
+				if err := outputStructured(store); err != nil {
+					return err
+				}
 			} else {
 				success(fmt.Sprintf("Store '%s' created", store.Slug))
 			}
@@ -147,11 +179,61 @@ func newStoreCreateCmd() *cobra.Command {
 	return cmd
 }
 
+// storeWithStats is a store with the counts shown by store show --stats.
+// A nil count means it could not be fetched, e.g. members for a non-admin.
+type storeWithStats struct {
+	*model.Store
+	RepoCount   *int `json:"repo_count"`
+	MemberCount *int `json:"member_count"`
+}
+
+// fetchStoreStats counts a store's repos and members. A count the caller
+// may not see (403 or 404) is left nil; any other failure is returned so a
+// broken server isn't mistaken for missing access.
+func fetchStoreStats(client *api.Client, store *model.Store) (storeWithStats, error) {
+	stats := storeWithStats{Store: store}
+
+	repos, err := client.ListRepos(store.Slug)
+	switch {
+	case err == nil:
+		n := len(repos)
+		stats.RepoCount = &n
+	case !isForbidden(err) && !isNotFound(err):
+		return stats, fmt.Errorf("failed to count repos: %w", err)
+	}
+
+	members, err := client.ListStoreMembers(store.Slug)
+	switch {
+	case err == nil:
+		n := len(members)
+		stats.MemberCount = &n
+	case !isForbidden(err) && !isNotFound(err):
+		return stats, fmt.Errorf("failed to count members: %w", err)
+	}
+	return stats, nil
+}
+
+// formatCount renders an optional count, using N/A when it is unknown.
+func formatCount(n *int) string {
+	if n == nil {
+		return "N/A"
+	}
+	return strconv.Itoa(*n)
+}
+
 func newStoreShowCmd() *cobra.Command {
+	var showStats bool
+
 	cmd := &cobra.Command{
-		Use:     "show <slug>",
-		Short:   "Show store details",
-		Example: "  scraps store show mystore",
+		Use:   "show <slug>",
+		Short: "Show store details",
+		Long: `Show store details.
+
+--stats also counts the store's repos and members. This takes two extra
+requests; a count you don't have access to (for example members, which
+require admin access) is shown as N/A.`,
+		Example: `  scraps store show mystore
+  scraps store show mystore --stats`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("store slug required\n\nUsage: scraps store show <slug>\n\nExample: scraps store show mystore")
@@ -169,17 +251,153 @@ func newStoreShowCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(store)
+			if !showStats {
+				if isStructuredOutput() {
+					return outputStructured(store)
+				}
+				printStore(store)
+				return nil
+			}
+
+			stats, err := fetchStoreStats(client, store)
+			if err != nil {
+				return err
+			}
+			if isStructuredOutput() {
+				if err := outputStructured(stats); err != nil {
+					return err
+				}
+			} else {
+				printStore(store)
+				fmt.Printf("Repos:      %s\n", formatCount(stats.RepoCount))
+				fmt.Printf("Members:    %s\n", formatCount(stats.MemberCount))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&showStats, "stats", false, "Also show repo and member counts")
+	return cmd
+}
+
+// printStore prints the labeled store details shown by store show.
+func printStore(store *model.Store) {
+	fmt.Printf("Slug:       %s\n", store.Slug)
+	fmt.Printf("ID:         %s\n", store.ID)
+	fmt.Printf("Role:       %s\n", store.Role)
+	fmt.Printf("Created:    %s\n", formatDateTime(store.CreatedAt))
+}
+
+func newStoreRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename <slug> <new-slug>",
+		Short: "Change a store's slug",
+		Long: `Change a store's slug.
+
+Clone URLs, git remotes and scoped tokens that refer to the old slug may stop
+working after the rename.`,
+		Example: "  scraps store rename mystore my-store",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("current and new slug required\n\nUsage: scraps store rename <slug> <new-slug>\n\nExample: scraps store rename mystore my-store")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slug, newSlug := args[0], args[1]
+			if err := validateSlug(newSlug); err != nil {
+				return err
+			}
+			if slug == newSlug {
+				return fmt.Errorf("store is already named '%s'", slug)
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			store, err := client.UpdateStore(slug, newSlug)
+			if err != nil {
+				return fmt.Errorf("failed to rename store '%s': %w", slug, err)
+			}
+
+			if isStructuredOutput() {
+				if err := outputStructured(store); err != nil {
+					return err
+				}
+			} else {
+				success(fmt.Sprintf("Store '%s' renamed to '%s'", slug, store.Slug))
+				warn(fmt.Sprintf("Clone URLs and tokens scoped to '%s' may no longer work", slug))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newStoreTransferCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "transfer <slug> <username>",
+		Short: "Transfer store ownership to another user",
+		Long: `Transfer store ownership to another user.
+
+You lose owner privileges on the store once the transfer completes. Only the
+current owner can transfer a store. Non-interactive use requires --force.`,
+		Example: "  scraps store transfer mystore johndoe\n  scraps store transfer mystore johndoe --force",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("store slug and new owner required\n\nUsage: scraps store transfer <slug> <username>\n\nExample: scraps store transfer mystore johndoe")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slug, username := args[0], args[1]
+
+			if !force {
+				if !isInteractive() {
+					return fmt.Errorf("transferring a store requires confirmation; pass --force to transfer non-interactively")
+				}
+				confirmed, err := components.RunConfirm(
+					"Transfer Store",
+					fmt.Sprintf("Transfer '%s' to '%s'?\nYou will lose owner privileges on this store.", slug, username),
+					true,
+				)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					info("Transfer cancelled")
+					return nil
+				}
+			}
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			if err := client.TransferStore(slug, username); err != nil {
+				if isForbidden(err) {
+					return describeError(err, "only the owner of '%s' can transfer it", slug)
+				}
+				return err
+			}
+
+			if isStructuredOutput() {
+				if err := outputStructured(map[string]string{"store": slug, "owner": username}); err != nil {
+					return err
+				}
 			} else {
-				fmt.Printf("Slug:       %s\n", store.Slug)
-				fmt.Printf("ID:         %s\n", store.ID)
-				fmt.Printf("Role:       %s\n", store.Role)
-				fmt.Printf("Created:    %s\n", formatDateTime(store.CreatedAt))
+				success(fmt.Sprintf("Store '%s' is now owned by %s", slug, username))
 			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
 	return cmd
 }
 
@@ -188,6 +406,7 @@ func newStoreDeleteCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:     "delete <slug>",
+		Aliases: []string{"rm"},
 		Short:   "Delete a store and all its repositories",
 		Example: "  scraps store delete mystore",
 		Args: func(cmd *cobra.Command, args []string) error {
@@ -201,10 +420,10 @@ func newStoreDeleteCmd() *cobra.Command {
 
 			// Confirm deletion
 			if !force && isInteractive() {
-				confirmed, err := components.RunConfirm(
+				confirmed, err := confirmDeletion(
 					"Delete Store",
 					fmt.Sprintf("Are you sure you want to delete '%s'?\nThis will delete ALL repositories in this store.\nThis cannot be undone.", slug),
-					true,
+					slug,
 				)
 				if err != nil {
 					return err
@@ -233,6 +452,16 @@ func newStoreDeleteCmd() *cobra.Command {
 	return cmd
 }
 
+// confirmDeletion asks the user to confirm deleting name. With
+// require_typed_confirmation set they must type the name; otherwise a
+// yes/no dialog showing message is used.
+func confirmDeletion(title, message, name string) (bool, error) {
+	if config.GetRequireTypedConfirmation() {
+		return components.RunTypedConfirm(title, name)
+	}
+	return components.RunConfirm(title, message, true)
+}
+
 // --- Store Members ---
 
 func newStoreMembersCmd() *cobra.Command {
@@ -250,6 +479,8 @@ func newStoreMembersCmd() *cobra.Command {
 }
 
 func newStoreMembersListCmd() *cobra.Command {
+	var fields []string
+
 	cmd := &cobra.Command{
 		Use:     "list <store>",
 		Short:   "List members of a store",
@@ -261,6 +492,11 @@ func newStoreMembersListCmd() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			headers := []string{"USERNAME", "ROLE", "ADDED"}
+			if err := validateFields(headers, fields); err != nil {
+				return err
+			}
+
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
 				return err
@@ -276,14 +512,19 @@ func newStoreMembersListCmd() *cobra.Command {
 				return nil
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(members)
-			} else {
-				headers := []string{"USERNAME", "ROLE", "ADDED"}
-				rows := make([][]string, len(members))
-				for i, m := range members {
-					rows[i] = []string{m.Username, m.Role, formatDate(m.CreatedAt)}
+			rows := make([][]string, len(members))
+			for i, m := range members {
+				rows[i] = []string{m.Username, m.Role, formatTableDate(m.CreatedAt)}
+			}
+			if len(fields) > 0 {
+				return outputFields(headers, rows, fields)
+			}
+
+			if isStructuredOutput() && !isDelimitedOutput() {
+				if err := outputStructured(members); err != nil {
+					return err
 				}
+			} else {
 
 				// Use interactive table if available
 				if isInteractive() {
@@ -294,30 +535,47 @@ func newStoreMembersListCmd() *cobra.Command {
 					if selected != nil {
 						fmt.Printf("\nSelected: %s (%s)\n", selected[0], selected[1])
 					}
-				} else {
-					outputTable(headers, rows)
+				} else if err := outputTable(headers, rows); err != nil {
+					return err
 				}
 			}
 			return nil
 		},
 	}
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Only show these columns, in this order (e.g. username,role)")
 	return cmd
 }
 
 func newStoreMembersAddCmd() *cobra.Command {
-	var role string
+	var role, fromFile string
+	var continueOnError bool
 
 	cmd := &cobra.Command{
-		Use:     "add <store> <username>",
-		Short:   "Add a member to a store",
-		Example: "  scraps store members add mystore johndoe --role member",
+		Use:   "add <store> <username>",
+		Short: "Add a member to a store",
+		Long: `Add a member to a store.
+
+With --from-file, members are read from a CSV or newline-delimited file with
+one "username,role" entry per line. The role column is optional and defaults
+to --role (or read). Blank lines and lines starting with # are ignored.`,
+		Example: "  scraps store members add mystore johndoe --role member\n  scraps store members add mystore --from-file team.csv",
 		Args: func(cmd *cobra.Command, args []string) error {
+			if fromFile != "" {
+				if len(args) != 1 {
+					return fmt.Errorf("store required (and no username) with --from-file\n\nUsage: scraps store members add <store> --from-file <path>")
+				}
+				return nil
+			}
 			if len(args) < 2 {
 				return fmt.Errorf("store and username required\n\nUsage: scraps store members add <store> <username>\n\nExample: scraps store members add mystore johndoe")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromFile != "" {
+				return addMembersFromFile(args[0], fromFile, role, continueOnError)
+			}
+
 			store, username := args[0], args[1]
 
 			// Interactive role selection if not provided
@@ -340,6 +598,9 @@ func newStoreMembersAddCmd() *cobra.Command {
 			if role == "" {
 				role = "read"
 			}
+			if err := validateRole(role, storeRoles); err != nil {
+				return err
+			}
 
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
@@ -351,8 +612,10 @@ func newStoreMembersAddCmd() *cobra.Command {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				outputJSON(member)
+			if isStructuredOutput() {
+				if err := outputStructured(member); err != nil {
+					return err
+				}
 			} else {
 				success(fmt.Sprintf("Added %s to %s with role %s", username, store, member.Role))
 			}
@@ -361,9 +624,127 @@ func newStoreMembersAddCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&role, "role", "r", "", "Member role (admin, member, read)")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Add members listed in a CSV file (username,role per line)")
+	cmd.Flags().BoolVar(&continueOnError, "continue-on-error", false, "Exit successfully even if some members could not be added")
 	return cmd
 }
 
+// memberEntry is one row of a members import file.
+type memberEntry struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// memberImportResult reports the outcome of adding one member from a file.
+type memberImportResult struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	Added    bool   `json:"added"`
+	Error    string `json:"error,omitempty"`
+}
+
+// parseMemberFile reads "username,role" rows. Rows without a role use
+// defaultRole, and a leading "username,role" header row is skipped.
+func parseMemberFile(r io.Reader, defaultRole string) ([]memberEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var entries []memberEntry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		username := strings.TrimSpace(record[0])
+		if username == "" {
+			continue
+		}
+		role := defaultRole
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			role = strings.TrimSpace(record[1])
+		}
+		if len(entries) == 0 && strings.EqualFold(username, "username") {
+			continue
+		}
+		entries = append(entries, memberEntry{Username: username, Role: role})
+	}
+	return entries, nil
+}
+
+// addMembersFromFile adds every member listed in path, continuing past
+// individual failures and reporting a summary at the end.
+func addMembersFromFile(store, path, defaultRole string, continueOnError bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if defaultRole == "" {
+		defaultRole = "read"
+	}
+	if err := validateRole(defaultRole, storeRoles); err != nil {
+		return err
+	}
+	entries, err := parseMemberFile(f, defaultRole)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no members found in %s", path)
+	}
+
+	client, err := api.NewClientFromConfig("")
+	if err != nil {
+		return err
+	}
+
+	results := make([]memberImportResult, len(entries))
+	failed := 0
+	for i, e := range entries {
+		results[i] = memberImportResult{Username: e.Username, Role: e.Role}
+		err := validateRole(e.Role, storeRoles)
+		var member *model.StoreMember
+		if err == nil {
+			member, err = client.AddStoreMember(store, e.Username, e.Role)
+		}
+		if err != nil {
+			failed++
+			results[i].Error = err.Error()
+			if !isStructuredOutput() {
+				errorf("%s: %v", e.Username, err)
+			}
+			continue
+		}
+		results[i].Added = true
+		if member != nil && member.Role != "" {
+			results[i].Role = member.Role
+		}
+		if !isStructuredOutput() {
+			success(fmt.Sprintf("Added %s with role %s", e.Username, results[i].Role))
+		}
+	}
+
+	if isStructuredOutput() {
+		if err := outputStructured(results); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n%d added, %d failed\n", len(entries)-failed, failed)
+	}
+
+	if failed > 0 && !continueOnError {
+		return fmt.Errorf("%d of %d members could not be added", failed, len(entries))
+	}
+	return nil
+}
+
 func newStoreMembersUpdateCmd() *cobra.Command {
 	var role string
 
@@ -383,6 +764,9 @@ func newStoreMembersUpdateCmd() *cobra.Command {
 			if role == "" {
 				return fmt.Errorf("role is required")
 			}
+			if err := validateRole(role, storeRoles); err != nil {
+				return err
+			}
 
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
@@ -426,6 +810,7 @@ func newStoreMembersRemoveCmd() *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:     "remove <store> <username>",
+		Aliases: []string{"rm"},
 		Short:   "Remove a member from a store",
 		Example: "  scraps store members remove mystore johndoe",
 		Args: func(cmd *cobra.Command, args []string) error {
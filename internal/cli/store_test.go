@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestParseMemberFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []memberEntry
+	}{
+		{
+			name:    "csv with header",
+			content: "username,role\nalice,admin\nbob,member\n",
+			want:    []memberEntry{{"alice", "admin"}, {"bob", "member"}},
+		},
+		{
+			name:    "newline delimited without roles",
+			content: "alice\nbob\n",
+			want:    []memberEntry{{"alice", "read"}, {"bob", "read"}},
+		},
+		{
+			name:    "comments, blanks and spaces",
+			content: "# team\n\nalice, admin\n  bob ,\n",
+			want:    []memberEntry{{"alice", "admin"}, {"bob", "read"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMemberFile(strings.NewReader(tt.content), "read")
+			if err != nil {
+				t.Fatalf("parseMemberFile() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMemberFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreCreateJSONStdout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/stores" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(model.Store{ID: "st_1", Slug: "foo"})
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "")
+	defer func() {
+		outputFormat = ""
+		rootCmd.SetArgs(nil)
+	}()
+
+	rootCmd.SetArgs([]string{"store", "create", "foo", "--output", "json"})
+	var err error
+	out := captureStdout(t, func() { err = rootCmd.Execute() })
+	if err != nil {
+		t.Fatalf("store create error = %v", err)
+	}
+
+	var store model.Store
+	if err := json.Unmarshal([]byte(out), &store); err != nil {
+		t.Fatalf("stdout is not only JSON: %v\n%s", err, out)
+	}
+	if store.Slug != "foo" {
+		t.Errorf("Slug = %q, want foo", store.Slug)
+	}
+}
+
+func TestStoreListFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]model.Store{
+			{Slug: "acme", Role: "owner", CreatedAt: "2024-03-15T10:30:00Z"},
+			{Slug: "labs, inc", Role: "member", CreatedAt: "2024-04-01T08:00:00Z"},
+		})
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "table", want: "ROLE    SLUG     \n------  ---------\nowner   acme     \nmember  labs, inc\n"},
+		{format: "csv", want: "ROLE,SLUG\nowner,acme\nmember,\"labs, inc\"\n"},
+		{format: "json", want: `[{"role":"owner","slug":"acme"},{"role":"member","slug":"labs, inc"}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Setenv("SCRAPS_OUTPUT_FORMAT", tt.format)
+			cmd := newStoreListCmd()
+			cmd.SetArgs([]string{"--fields", "role,slug"})
+			var err error
+			out := captureStdout(t, func() { err = cmd.Execute() })
+			if err != nil {
+				t.Fatalf("store list error = %v", err)
+			}
+			if tt.format == "json" {
+				var compact bytes.Buffer
+				json.Compact(&compact, []byte(out))
+				out = compact.String()
+			}
+			if out != tt.want {
+				t.Errorf("output = %q, want %q", out, tt.want)
+			}
+		})
+	}
+
+	cmd := newStoreListCmd()
+	cmd.SetArgs([]string{"--fields", "owner"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "available: slug, role, created") {
+		t.Errorf("store list --fields owner error = %v, want the available fields", err)
+	}
+}
+
+func TestStoreShowStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/stores/acme":
+			json.NewEncoder(w).Encode(model.Store{ID: "st_1", Slug: "acme", Role: "member"})
+		case "/api/v1/stores/acme/repos":
+			json.NewEncoder(w).Encode([]model.Repository{{Name: "api"}, {Name: "web"}})
+		case "/api/v1/stores/acme/members":
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]string{"error": "admin access required"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+
+	t.Run("table", func(t *testing.T) {
+		t.Setenv("SCRAPS_OUTPUT_FORMAT", "table")
+		cmd := newStoreShowCmd()
+		cmd.SetArgs([]string{"acme", "--stats"})
+		var err error
+		out := captureStdout(t, func() { err = cmd.Execute() })
+		if err != nil {
+			t.Fatalf("store show --stats error = %v", err)
+		}
+		for _, want := range []string{"Repos:      2\n", "Members:    N/A\n"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("output missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+		cmd := newStoreShowCmd()
+		cmd.SetArgs([]string{"acme", "--stats"})
+		var err error
+		out := captureStdout(t, func() { err = cmd.Execute() })
+		if err != nil {
+			t.Fatalf("store show --stats error = %v", err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("stdout is not JSON: %v\n%s", err, out)
+		}
+		if got["slug"] != "acme" || got["repo_count"] != float64(2) || got["member_count"] != nil {
+			t.Errorf("output = %v, want slug acme, repo_count 2, member_count null", got)
+		}
+	})
+}
+
+func TestStoreShowStatsServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/stores/acme":
+			json.NewEncoder(w).Encode(model.Store{ID: "st_1", Slug: "acme"})
+		case "/api/v1/stores/acme/repos":
+			json.NewEncoder(w).Encode([]model.Repository{})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": "database unavailable"})
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "table")
+
+	// Only missing access is shown as N/A; a server error fails the command
+	cmd := newStoreShowCmd()
+	cmd.SetArgs([]string{"acme", "--stats"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	var err error
+	captureStdout(t, func() { err = cmd.Execute() })
+	if err == nil || !strings.Contains(err.Error(), "failed to count members") {
+		t.Errorf("store show --stats error = %v, want the members failure", err)
+	}
+}
@@ -2,13 +2,17 @@ package cli
 
 import (
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
 	"github.com/morrisclay/scraps-cli/internal/api"
-	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/model"
 	"github.com/morrisclay/scraps-cli/internal/tui"
 	"github.com/morrisclay/scraps-cli/internal/tui/components"
 )
@@ -21,15 +25,16 @@ func newTokenCmd() *cobra.Command {
 
 	cmd.AddCommand(newTokenCreateCmd())
 	cmd.AddCommand(newTokenListCmd())
+	cmd.AddCommand(newTokenShowCmd())
 	cmd.AddCommand(newTokenRevokeCmd())
+	cmd.AddCommand(newTokenRotateCmd())
 
 	return cmd
 }
 
 func newTokenCreateCmd() *cobra.Command {
-	var name, store, repo, permission string
-	var scoped bool
-	var expires int
+	var name, store, repo, permission, expires string
+	var scoped, copyKey bool
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -41,7 +46,7 @@ func newTokenCreateCmd() *cobra.Command {
 			}
 
 			// Interactive wizard mode
-			if isInteractive() && !scoped && name == "" {
+			if isInteractive() && !scoped && name == "" && !copyKey {
 				return runTokenWizard(client)
 			}
 
@@ -57,16 +62,40 @@ func newTokenCreateCmd() *cobra.Command {
 					repos = strings.Split(repo, ",")
 				}
 
-				resp, err := client.CreateScopedToken(name, store, repos, permissions, expires)
+				expiresInDays := 0
+				if expires != "" {
+					expiresInDays, err = parseExpiresDays(expires)
+					if err != nil {
+						return err
+					}
+				}
+
+				resp, err := client.CreateScopedToken(name, store, repos, permissions, expiresInDays)
 				if err != nil {
 					return err
 				}
 
-				if config.GetOutputFormat() == "json" {
-					outputJSON(resp)
+				rawKey := resp.RawKey
+				resp.RawKey = maskSecret(resp.RawKey)
+				if isStructuredOutput() {
+					if err := outputStructured(resp); err != nil {
+						return err
+					}
 				} else {
 					success("Scoped token created")
-					fmt.Printf("\nToken: %s\n", resp.RawKey)
+					fmt.Println()
+					if copyKey {
+						copyToClipboard("Token", rawKey)
+					} else {
+						fmt.Printf("Token: %s\n", resp.RawKey)
+					}
+					if expiresInDays > 0 {
+						expiresAt := time.Now().AddDate(0, 0, expiresInDays).Format(time.RFC3339)
+						if resp.ExpiresAt != nil {
+							expiresAt = *resp.ExpiresAt
+						}
+						fmt.Printf("Expires: %s (%d days)\n", formatDateTime(expiresAt), expiresInDays)
+					}
 					fmt.Println("\nSave this token - it won't be shown again!")
 				}
 			} else {
@@ -76,11 +105,20 @@ func newTokenCreateCmd() *cobra.Command {
 					return err
 				}
 
-				if config.GetOutputFormat() == "json" {
-					outputJSON(resp)
+				rawKey := resp.RawKey
+				resp.RawKey = maskSecret(resp.RawKey)
+				if isStructuredOutput() {
+					if err := outputStructured(resp); err != nil {
+						return err
+					}
 				} else {
 					success("API key created")
-					fmt.Printf("\nKey: %s\n", resp.RawKey)
+					fmt.Println()
+					if copyKey {
+						copyToClipboard("Key", rawKey)
+					} else {
+						fmt.Printf("Key: %s\n", resp.RawKey)
+					}
 					fmt.Println("\nSave this key - it won't be shown again!")
 				}
 			}
@@ -90,14 +128,37 @@ func newTokenCreateCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Token name/label")
 	cmd.Flags().BoolVar(&scoped, "scoped", false, "Create scoped token instead of API key")
+	cmd.Flags().BoolVar(&copyKey, "copy", false, "Copy the new key to the clipboard instead of printing it")
 	cmd.Flags().StringVarP(&store, "store", "s", "", "Store ID for scoped token")
 	cmd.Flags().StringVarP(&repo, "repo", "r", "", "Repository names (comma-separated) for scoped token")
 	cmd.Flags().StringVarP(&permission, "permission", "p", "read", "Permission (read, write)")
-	cmd.Flags().IntVar(&expires, "expires", 0, "Expiration in days")
+	cmd.Flags().StringVar(&expires, "expires", "", "Expiration for scoped token (e.g. 30d, 1w, 12h; bare number = days)")
 
 	return cmd
 }
 
+// parseExpiresDays converts an --expires value to whole days, rounding up.
+// A bare integer is interpreted as days for backward compatibility.
+func parseExpiresDays(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("--expires must be positive, got %q", s)
+		}
+		return n, nil
+	}
+
+	d, err := parseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --expires value: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("--expires must be positive, got %q", s)
+	}
+
+	day := 24 * time.Hour
+	return int((d + day - 1) / day), nil
+}
+
 // tokenWizardModel is the wizard for creating tokens.
 type tokenWizardModel struct {
 	client     *api.Client
@@ -111,12 +172,13 @@ type tokenWizardModel struct {
 	expires    int
 	stores     []string
 	allRepos   []string
+	height     int
 
 	// Sub-components
 	typeSelect   *components.SelectStep
 	nameInput    *components.TextInputStep
 	storeSelect  *components.ItemSelectStep
-	repoSelect   *components.SelectStep
+	repoSelect   *components.MultiSelectStep
 	permSelect   *components.SelectStep
 
 	state  string // "type", "name", "store", "repo", "perm", "creating", "done", "error"
@@ -168,12 +230,23 @@ func (m tokenWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
-		if msg.String() == "esc" && m.current > 0 {
+		if msg.String() == "esc" && m.current > 0 && !m.stepFiltering() {
 			m.current--
 			m.state = m.steps[m.current]
 			return m, nil
 		}
 
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.typeSelect.SetHeight(msg.Height)
+		if m.storeSelect != nil {
+			m.storeSelect.SetHeight(msg.Height)
+		}
+		if m.permSelect != nil {
+			m.permSelect.SetHeight(msg.Height)
+		}
+		return m, nil
+
 	case storesLoadedMsg:
 		if msg.err != nil {
 			m.state = "error"
@@ -186,6 +259,7 @@ func (m tokenWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			items[i] = components.NewSearchListItem(s, "", s)
 		}
 		m.storeSelect = components.NewItemSelectStep("Select Store", "Choose a store:", items)
+		m.storeSelect.SetHeight(m.height)
 		return m, nil
 
 	case reposLoadedMsg:
@@ -195,8 +269,11 @@ func (m tokenWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 		m.allRepos = msg.repos
-		options := append([]string{"All repositories"}, msg.repos...)
-		m.repoSelect = components.NewSelectStep("Select Repository", "Choose repositories:", options)
+		m.repoSelect = components.NewMultiSelectStep(
+			"Select Repositories",
+			"Choose repositories (none selected = all repositories):",
+			msg.repos,
+		)
 		return m, nil
 
 	case tokenCreatedMsg:
@@ -291,17 +368,17 @@ func (m tokenWizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil // waiting for repos to load
 		}
 		step, cmd := m.repoSelect.Update(msg)
-		m.repoSelect = step.(*components.SelectStep)
+		m.repoSelect = step.(*components.MultiSelectStep)
 		if m.repoSelect.IsComplete() {
-			selected := m.repoSelect.Value().(string)
-			if selected == "All repositories" {
-				m.repos = nil
-			} else {
-				m.repos = []string{selected}
+			// An empty selection scopes the token to every repository
+			m.repos = nil
+			if selected := m.repoSelect.Selected(); len(selected) > 0 {
+				m.repos = selected
 			}
 			m.state = "perm"
 			m.current = 4
 			m.permSelect = components.NewSelectStep("Permission", "Choose permission level:", []string{"read", "write"})
+			m.permSelect.SetHeight(m.height)
 			return m, nil
 		}
 		return m, cmd
@@ -357,7 +434,7 @@ func (m tokenWizardModel) View() string {
 		}
 
 	case "repo":
-		s.WriteString("Step 4 of 5: Select Repository\n\n")
+		s.WriteString("Step 4 of 5: Select Repositories\n\n")
 		if m.repoSelect != nil {
 			s.WriteString(m.repoSelect.View())
 		} else {
@@ -374,7 +451,16 @@ func (m tokenWizardModel) View() string {
 	case "done":
 		s.WriteString(tui.SuccessStyle.Render("✓ Token created!\n\n"))
 		s.WriteString(tui.LabelStyle.Render("Token: "))
-		s.WriteString(m.result)
+		s.WriteString(maskSecret(m.result))
+		if m.tokenType == "scoped" {
+			repos := "all"
+			if len(m.repos) > 0 {
+				repos = strings.Join(m.repos, ", ")
+			}
+			s.WriteString("\n")
+			s.WriteString(tui.LabelStyle.Render("Repos: "))
+			s.WriteString(repos)
+		}
 		s.WriteString("\n\n")
 		s.WriteString(tui.WarningStyle.Render("Save this token - it won't be shown again!"))
 
@@ -382,12 +468,37 @@ func (m tokenWizardModel) View() string {
 		s.WriteString(tui.ErrorStyle.Render(fmt.Sprintf("✗ Error: %v", m.err)))
 	}
 
+	helpText := "↑↓ navigate  enter select"
+	switch {
+	case m.state == "type":
+		helpText = m.typeSelect.HelpText()
+	case m.state == "store" && m.storeSelect != nil:
+		helpText = m.storeSelect.HelpText()
+	case m.state == "repo" && m.repoSelect != nil:
+		helpText = m.repoSelect.HelpText()
+	case m.state == "perm" && m.permSelect != nil:
+		helpText = m.permSelect.HelpText()
+	}
 	s.WriteString("\n\n")
-	s.WriteString(tui.HelpStyle.Render("↑↓ navigate  enter select  esc back"))
+	s.WriteString(tui.HelpStyle.Render(helpText + "  esc back"))
 
 	return tui.BoxStyle.Render(s.String())
 }
 
+// stepFiltering reports whether the current step is filtering its options,
+// in which case esc clears the filter instead of going back.
+func (m tokenWizardModel) stepFiltering() bool {
+	switch m.state {
+	case "type":
+		return m.typeSelect.Filtering()
+	case "store":
+		return m.storeSelect != nil && m.storeSelect.Filtering()
+	case "perm":
+		return m.permSelect != nil && m.permSelect.Filtering()
+	}
+	return false
+}
+
 func runTokenWizard(client *api.Client) error {
 	m := newTokenWizardModel(client)
 	p := tea.NewProgram(m)
@@ -404,140 +515,374 @@ func runTokenWizard(client *api.Client) error {
 
 func newTokenListCmd() *cobra.Command {
 	var keysOnly, tokensOnly bool
+	var warnWithin, expiring string
+	var fields []string
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List API keys and scoped tokens",
+		Long: `List API keys and scoped tokens.
+
+Tokens that expire within --warn-within (7 days by default) are marked with
+"!" in the EXPIRES IN column. --expiring shows only the tokens that expire
+within the given window, including any already expired, and exits non-zero
+if there are any, so a cron job can alert before automation breaks.`,
+		Example: "  scraps token list\n  scraps token list --tokens --warn-within 14d\n  scraps token list --expiring 7d",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateFields(tokenTableHeaders, fields); err != nil {
+				return err
+			}
+			warnWindow, err := parseDuration(warnWithin)
+			if err != nil {
+				return fmt.Errorf("invalid --warn-within value: %w", err)
+			}
+			var expiringWindow time.Duration
+			if expiring != "" {
+				expiringWindow, err = parseDuration(expiring)
+				if err != nil {
+					return fmt.Errorf("invalid --expiring value: %w", err)
+				}
+				warnWindow = expiringWindow
+			}
+
 			client, err := api.NewClientFromConfig("")
 			if err != nil {
 				return err
 			}
 
-			if config.GetOutputFormat() == "json" {
-				result := map[string]any{}
+			var keys []model.APIKey
+			if !tokensOnly {
+				keys, err = client.ListAPIKeys()
+				if err != nil {
+					return err
+				}
+			}
+			var tokens []model.ScopedToken
+			if !keysOnly {
+				tokens, err = client.ListScopedTokens()
+				if err != nil {
+					return err
+				}
+			}
 
-				if !tokensOnly {
-					keys, err := client.ListAPIKeys()
-					if err != nil {
+			now := time.Now()
+			if expiring != "" {
+				keys = slices.DeleteFunc(keys, func(k model.APIKey) bool {
+					return !expiresWithin(k.ExpiresAt, now, expiringWindow)
+				})
+				tokens = slices.DeleteFunc(tokens, func(t model.ScopedToken) bool {
+					return !expiresWithin(t.ExpiresAt, now, expiringWindow)
+				})
+			}
+
+			// With --expiring, any match is a failure for scripts to act on
+			var expiringErr error
+			if n := len(keys) + len(tokens); expiring != "" && n > 0 {
+				expiringErr = fmt.Errorf("%d token(s) expire within %s", n, expiring)
+			}
+
+			if isDelimitedOutput() || len(fields) > 0 {
+				// One table for both kinds, with IDs in full
+				headers, rows := tokenTable(keys, tokens, now, warnWindow)
+				if len(fields) > 0 {
+					if err := outputFields(headers, rows, fields); err != nil {
 						return err
 					}
-					result["api_keys"] = keys
+					if expiringErr != nil && !isDelimitedOutput() && isStructuredOutput() {
+						return &reportedError{cause: expiringErr}
+					}
+				} else if err := outputDelimited(headers, rows); err != nil {
+					return err
 				}
+				return expiringErr
+			}
 
+			if isStructuredOutput() {
+				result := map[string]any{}
+				if !tokensOnly {
+					result["api_keys"] = keys
+				}
 				if !keysOnly {
-					tokens, err := client.ListScopedTokens()
-					if err != nil {
-						return err
-					}
 					result["scoped_tokens"] = tokens
 				}
+				if err := outputStructured(result); err != nil {
+					return err
+				}
+				if expiringErr != nil {
+					return &reportedError{cause: expiringErr}
+				}
+				return nil
+			}
 
-				outputJSON(result)
+			if expiring != "" && expiringErr == nil {
+				info(fmt.Sprintf("No tokens expire within %s", expiring))
 				return nil
 			}
 
 			// Table output
-			if !tokensOnly {
-				keys, err := client.ListAPIKeys()
-				if err != nil {
-					return err
+			if len(keys) > 0 {
+				fmt.Println("API Keys:")
+				headers := []string{"ID", "LABEL", "PREFIX", "CREATED", "LAST USED", "EXPIRES IN"}
+				rows := make([][]string, len(keys))
+				for i, k := range keys {
+					lastUsed := "-"
+					if k.LastUsedAt != nil {
+						lastUsed = formatTableDateTime(*k.LastUsedAt)
+					}
+					rows[i] = []string{
+						truncate(k.ID, 12),
+						k.Label,
+						k.KeyPrefix,
+						formatTableDate(k.CreatedAt),
+						lastUsed,
+						formatExpiresIn(k.ExpiresAt, now, warnWindow),
+					}
 				}
 
-				if len(keys) > 0 {
-					fmt.Println("API Keys:")
-					headers := []string{"ID", "LABEL", "PREFIX", "CREATED", "LAST USED"}
-					rows := make([][]string, len(keys))
-					for i, k := range keys {
-						lastUsed := "-"
-						if k.LastUsedAt != nil {
-							lastUsed = formatDateTime(*k.LastUsedAt)
-						}
-						rows[i] = []string{
-							truncate(k.ID, 12),
-							k.Label,
-							k.KeyPrefix,
-							formatDate(k.CreatedAt),
-							lastUsed,
-						}
+				// Use interactive table if available
+				if isInteractive() {
+					selected, err := outputInteractiveTable("API Keys", headers, rows)
+					if err != nil {
+						return err
 					}
-
-					// Use interactive table if available
-					if isInteractive() {
-						selected, err := outputInteractiveTable("API Keys", headers, rows)
-						if err != nil {
-							return err
-						}
-						if selected != nil {
-							// Copy full ID to show user what was selected
-							for _, k := range keys {
-								if truncate(k.ID, 12) == selected[0] {
-									fmt.Printf("\nSelected: %s (ID: %s)\n", k.Label, k.ID)
-									break
-								}
+					if selected != nil {
+						// Copy full ID to show user what was selected
+						for _, k := range keys {
+							if truncate(k.ID, 12) == selected[0] {
+								fmt.Printf("\nSelected: %s (ID: %s)\n", k.Label, k.ID)
+								break
 							}
 						}
-					} else {
-						outputTable(headers, rows)
 					}
-					fmt.Println()
+				} else if err := outputTable(headers, rows); err != nil {
+					return err
 				}
+				fmt.Println()
 			}
 
-			if !keysOnly {
-				tokens, err := client.ListScopedTokens()
-				if err != nil {
-					return err
+			if len(tokens) > 0 {
+				fmt.Println("Scoped Tokens:")
+				headers := []string{"ID", "LABEL", "PERMISSIONS", "CREATED", "EXPIRES", "EXPIRES IN"}
+				rows := make([][]string, len(tokens))
+				for i, t := range tokens {
+					expires := "-"
+					if t.ExpiresAt != nil {
+						expires = formatTableDate(*t.ExpiresAt)
+					}
+					rows[i] = []string{
+						truncate(t.ID, 12),
+						t.Label,
+						strings.Join(t.Scope.Permissions, ","),
+						formatTableDate(t.CreatedAt),
+						expires,
+						formatExpiresIn(t.ExpiresAt, now, warnWindow),
+					}
 				}
 
-				if len(tokens) > 0 {
-					fmt.Println("Scoped Tokens:")
-					headers := []string{"ID", "LABEL", "PERMISSIONS", "CREATED", "EXPIRES"}
-					rows := make([][]string, len(tokens))
-					for i, t := range tokens {
-						expires := "-"
-						if t.ExpiresAt != nil {
-							expires = formatDate(*t.ExpiresAt)
-						}
-						rows[i] = []string{
-							truncate(t.ID, 12),
-							t.Label,
-							strings.Join(t.Scope.Permissions, ","),
-							formatDate(t.CreatedAt),
-							expires,
-						}
+				// Use interactive table if available
+				if isInteractive() {
+					selected, err := outputInteractiveTable("Scoped Tokens", headers, rows)
+					if err != nil {
+						return err
 					}
-
-					// Use interactive table if available
-					if isInteractive() {
-						selected, err := outputInteractiveTable("Scoped Tokens", headers, rows)
-						if err != nil {
-							return err
-						}
-						if selected != nil {
-							for _, t := range tokens {
-								if truncate(t.ID, 12) == selected[0] {
-									fmt.Printf("\nSelected: %s (ID: %s)\n", t.Label, t.ID)
-									break
-								}
+					if selected != nil {
+						for _, t := range tokens {
+							if truncate(t.ID, 12) == selected[0] {
+								fmt.Printf("\nSelected: %s (ID: %s)\n", t.Label, t.ID)
+								break
 							}
 						}
-					} else {
-						outputTable(headers, rows)
 					}
+				} else if err := outputTable(headers, rows); err != nil {
+					return err
 				}
 			}
 
+			if expiringErr != nil {
+				return expiringErr
+			}
+			n := 0
+			for _, k := range keys {
+				if expiresWithin(k.ExpiresAt, now, warnWindow) {
+					n++
+				}
+			}
+			for _, t := range tokens {
+				if expiresWithin(t.ExpiresAt, now, warnWindow) {
+					n++
+				}
+			}
+			if n > 0 {
+				warn(fmt.Sprintf("%d token(s) expire within %s", n, warnWithin))
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&keysOnly, "keys", false, "Show only API keys")
 	cmd.Flags().BoolVar(&tokensOnly, "tokens", false, "Show only scoped tokens")
+	cmd.Flags().StringVar(&warnWithin, "warn-within", "7d", "Mark tokens expiring within this window (e.g. 7d, 2w, 12h)")
+	cmd.Flags().StringVar(&expiring, "expiring", "", "Show only tokens expiring within this window and exit non-zero if any")
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Only show these columns, in this order (e.g. type,id,expires_in)")
 
 	return cmd
 }
 
+// tokenTableHeaders are the columns of tokenTable.
+var tokenTableHeaders = []string{"TYPE", "ID", "LABEL", "PERMISSIONS", "CREATED", "LAST USED", "EXPIRES", "EXPIRES IN"}
+
+// tokenTable lists API keys and scoped tokens in one table, used for
+// delimited output and --fields.
+func tokenTable(keys []model.APIKey, tokens []model.ScopedToken, now time.Time, warnWindow time.Duration) ([]string, [][]string) {
+	var rows [][]string
+	for _, k := range keys {
+		rows = append(rows, []string{
+			"api_key", k.ID, k.Label, "",
+			formatTableDate(k.CreatedAt),
+			formatOptionalTableDateTime(k.LastUsedAt),
+			formatOptionalTableDateTime(k.ExpiresAt),
+			formatExpiresIn(k.ExpiresAt, now, warnWindow),
+		})
+	}
+	for _, t := range tokens {
+		rows = append(rows, []string{
+			"scoped_token", t.ID, t.Label, strings.Join(t.Scope.Permissions, ","),
+			formatTableDate(t.CreatedAt),
+			formatOptionalTableDateTime(t.LastUsedAt),
+			formatOptionalTableDateTime(t.ExpiresAt),
+			formatExpiresIn(t.ExpiresAt, now, warnWindow),
+		})
+	}
+	return tokenTableHeaders, rows
+}
+
+// expiresWithin reports whether an expiry time falls within window of now.
+// Tokens that have already expired count; tokens without an expiry don't.
+func expiresWithin(expiresAt *string, now time.Time, window time.Duration) bool {
+	if expiresAt == nil {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, *expiresAt)
+	if err != nil {
+		return false
+	}
+	return t.Sub(now) <= window
+}
+
+// formatExpiresIn formats the time left until expiresAt, e.g. "3 days",
+// prefixed with "!" when it falls within the warning window.
+func formatExpiresIn(expiresAt *string, now time.Time, warnWindow time.Duration) string {
+	if expiresAt == nil {
+		return "-"
+	}
+	t, err := time.Parse(time.RFC3339, *expiresAt)
+	if err != nil {
+		return "-"
+	}
+
+	left := formatTimeLeft(t.Sub(now))
+	if expiresWithin(expiresAt, now, warnWindow) {
+		return "! " + left
+	}
+	return left
+}
+
+// formatTimeLeft formats a remaining duration in its largest whole unit.
+func formatTimeLeft(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "expired"
+	case d >= 24*time.Hour:
+		return plural(int(d/(24*time.Hour)), "day")
+	case d >= time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	case d >= time.Minute:
+		return plural(int(d/time.Minute), "minute")
+	}
+	return "< 1 minute"
+}
+
+func newTokenShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "show <id>",
+		Short:   "Show details of an API key or scoped token",
+		Example: "  scraps token show abc123",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("token ID required\n\nUsage: scraps token show <id>\n\nExample: scraps token show abc123")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			key, token, err := getTokenByID(client, id)
+			if err != nil {
+				return err
+			}
+			if key != nil {
+				if isStructuredOutput() {
+					return outputStructured(key)
+				}
+				fmt.Printf("Type:        API key\n")
+				fmt.Printf("ID:          %s\n", key.ID)
+				fmt.Printf("Label:       %s\n", key.Label)
+				fmt.Printf("Prefix:      %s\n", key.KeyPrefix)
+				fmt.Printf("Created:     %s\n", formatDateTime(key.CreatedAt))
+				fmt.Printf("Last Used:   %s\n", formatOptionalDateTime(key.LastUsedAt))
+				fmt.Printf("Expires:     %s\n", formatOptionalDateTime(key.ExpiresAt))
+				return nil
+			}
+
+			if isStructuredOutput() {
+				return outputStructured(token)
+			}
+
+			storeID := "-"
+			if token.Scope.StoreID != nil {
+				storeID = *token.Scope.StoreID
+			}
+			repos := "all"
+			if len(token.Scope.Repos) > 0 {
+				repos = strings.Join(token.Scope.Repos, ", ")
+			}
+			fmt.Printf("Type:        Scoped token\n")
+			fmt.Printf("ID:          %s\n", token.ID)
+			fmt.Printf("Label:       %s\n", token.Label)
+			fmt.Printf("Store ID:    %s\n", storeID)
+			fmt.Printf("Repos:       %s\n", repos)
+			fmt.Printf("Permissions: %s\n", strings.Join(token.Scope.Permissions, ", "))
+			fmt.Printf("Created:     %s\n", formatDateTime(token.CreatedAt))
+			fmt.Printf("Last Used:   %s\n", formatOptionalDateTime(token.LastUsedAt))
+			fmt.Printf("Expires:     %s\n", formatOptionalDateTime(token.ExpiresAt))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// formatOptionalTableDateTime is formatOptionalDateTime for table columns,
+// which may show the date relative to now.
+func formatOptionalTableDateTime(dateStr *string) string {
+	if dateStr == nil {
+		return "-"
+	}
+	return formatTableDateTime(*dateStr)
+}
+
+// formatOptionalDateTime formats an optional datetime, returning "-" when absent.
+func formatOptionalDateTime(dateStr *string) string {
+	if dateStr == nil || *dateStr == "" {
+		return "-"
+	}
+	return formatDateTime(*dateStr)
+}
+
 func newTokenRevokeCmd() *cobra.Command {
 	var isToken, force bool
 
@@ -599,3 +944,176 @@ func newTokenRevokeCmd() *cobra.Command {
 
 	return cmd
 }
+
+// tokenRotation is the structured output of token rotate.
+type tokenRotation struct {
+	*model.TokenCreateResponse
+	ReplacedID  string `json:"replaced_id"`
+	Revoked     bool   `json:"revoked"`
+	RevokeError string `json:"revoke_error,omitempty"`
+}
+
+func newTokenRotateCmd() *cobra.Command {
+	var keepOld, force, copyKey bool
+
+	cmd := &cobra.Command{
+		Use:   "rotate <id>",
+		Short: "Replace an API key or scoped token with a new one",
+		Long: `Replace an API key or scoped token with a new one.
+
+A new key is created with the old one's label, and for scoped tokens the
+same store, repositories, permissions and lifetime. The old key is then
+revoked unless --keep-old is given, so consumers can be moved over first.`,
+		Example: `  scraps token rotate abc123
+  scraps token rotate abc123 --keep-old
+  scraps token rotate abc123 --force --copy`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return fmt.Errorf("token ID required\n\nUsage: scraps token rotate <id>\n\nExample: scraps token rotate abc123")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+
+			client, err := api.NewClientFromConfig("")
+			if err != nil {
+				return err
+			}
+
+			// Look up the old key first so the replacement can copy it
+			key, token, err := getTokenByID(client, id)
+			if err != nil {
+				return err
+			}
+			tokenType, label := "API key", ""
+			if key != nil {
+				label = key.Label
+			} else {
+				tokenType, label = "scoped token", token.Label
+			}
+
+			if !keepOld && !force && isInteractive() {
+				confirmed, err := components.RunConfirm(
+					"Rotate Token",
+					fmt.Sprintf("Create a replacement and revoke this %s?\nID: %s", tokenType, id),
+					true,
+				)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					info("Rotation cancelled")
+					return nil
+				}
+			}
+
+			var resp *model.TokenCreateResponse
+			if key != nil {
+				resp, err = client.CreateAPIKey(label)
+			} else {
+				storeID := ""
+				if token.Scope.StoreID != nil {
+					storeID = *token.Scope.StoreID
+				}
+				resp, err = client.CreateScopedToken(label, storeID, token.Scope.Repos, token.Scope.Permissions, tokenLifetimeDays(token))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to create replacement: %w", err)
+			}
+
+			result := tokenRotation{TokenCreateResponse: resp, ReplacedID: id}
+			var revokeErr error
+			if !keepOld {
+				if key != nil {
+					revokeErr = client.RevokeAPIKey(id)
+				} else {
+					revokeErr = client.RevokeScopedToken(id)
+				}
+				result.Revoked = revokeErr == nil
+				if revokeErr != nil {
+					result.RevokeError = revokeErr.Error()
+					revokeErr = fmt.Errorf("replacement created, but revoking %s failed: %w", id, revokeErr)
+				}
+			}
+
+			// The new key must be shown even if the revoke failed
+			rawKey := resp.RawKey
+			resp.RawKey = maskSecret(resp.RawKey)
+			if isStructuredOutput() {
+				if err := outputStructured(result); err != nil {
+					return err
+				}
+				if revokeErr != nil {
+					return &reportedError{cause: revokeErr}
+				}
+				return nil
+			}
+
+			if key != nil {
+				success("API key rotated")
+			} else {
+				success("Scoped token rotated")
+			}
+			fmt.Println()
+			if copyKey {
+				copyToClipboard("Key", rawKey)
+			} else {
+				fmt.Printf("Key: %s\n", resp.RawKey)
+			}
+			fmt.Printf("New ID: %s\n", resp.ID)
+			switch {
+			case keepOld:
+				fmt.Printf("Old ID: %s (kept; revoke it with 'scraps token revoke %s')\n", id, id)
+			case result.Revoked:
+				fmt.Printf("Old ID: %s (revoked)\n", id)
+			}
+			fmt.Println("\nSave this key - it won't be shown again!")
+			return revokeErr
+		},
+	}
+
+	cmd.Flags().BoolVar(&keepOld, "keep-old", false, "Don't revoke the old key")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&copyKey, "copy", false, "Copy the new key to the clipboard instead of printing it")
+
+	return cmd
+}
+
+// getTokenByID looks id up as an API key, then as a scoped token. Exactly
+// one of the results is non-nil when err is nil.
+func getTokenByID(client *api.Client, id string) (*model.APIKey, *model.ScopedToken, error) {
+	key, err := client.GetAPIKey(id)
+	if err == nil {
+		return key, nil, nil
+	}
+	if !isNotFound(err) {
+		return nil, nil, err
+	}
+
+	token, err := client.GetScopedToken(id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil, describeError(err, "token not found: %s", id)
+		}
+		return nil, nil, err
+	}
+	return nil, token, nil
+}
+
+// tokenLifetimeDays returns how many days a scoped token was issued for,
+// rounded up, or 0 if it never expires or the dates can't be read.
+func tokenLifetimeDays(token *model.ScopedToken) int {
+	if token.ExpiresAt == nil {
+		return 0
+	}
+	created, err := time.Parse(time.RFC3339, token.CreatedAt)
+	if err != nil {
+		return 0
+	}
+	expires, err := time.Parse(time.RFC3339, *token.ExpiresAt)
+	if err != nil || !expires.After(created) {
+		return 0
+	}
+	return int(math.Ceil(expires.Sub(created).Hours() / 24))
+}
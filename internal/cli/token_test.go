@@ -0,0 +1,308 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestParseExpiresDays(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    int
+		wantErr bool
+	}{
+		{name: "bare integer", s: "30", want: 30},
+		{name: "days", s: "30d", want: 30},
+		{name: "week", s: "1w", want: 7},
+		{name: "hours round up", s: "12h", want: 1},
+		{name: "just over a day", s: "25h", want: 2},
+		{name: "zero", s: "0", wantErr: true},
+		{name: "negative integer", s: "-3", wantErr: true},
+		{name: "negative duration", s: "-12h", wantErr: true},
+		{name: "zero duration", s: "0d", wantErr: true},
+		{name: "invalid", s: "forever", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExpiresDays(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseExpiresDays(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseExpiresDays(%q) = %d, want %d", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenRotate(t *testing.T) {
+	storeID := "st_1"
+	expires := "2026-01-31T00:00:00Z"
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantCreate  string
+		wantBody    map[string]any
+		wantRevoked string
+	}{
+		{
+			name:        "api key",
+			args:        []string{"key_1", "--force"},
+			wantCreate:  "/api/v1/api-keys",
+			wantBody:    map[string]any{"label": "ci"},
+			wantRevoked: "/api/v1/api-keys/key_1",
+		},
+		{
+			name:       "scoped token",
+			args:       []string{"tok_1", "--force"},
+			wantCreate: "/api/v1/scoped-tokens",
+			wantBody: map[string]any{
+				"label":           "deploy",
+				"store_id":        "st_1",
+				"repos":           []any{"web"},
+				"permissions":     []any{"write"},
+				"expires_in_days": float64(30),
+			},
+			wantRevoked: "/api/v1/scoped-tokens/tok_1",
+		},
+		{
+			name:       "keep old",
+			args:       []string{"key_1", "--keep-old"},
+			wantCreate: "/api/v1/api-keys",
+			wantBody:   map[string]any{"label": "ci"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, revoked string
+			var body map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v1/api-keys/key_1":
+					json.NewEncoder(w).Encode(model.APIKey{ID: "key_1", Label: "ci"})
+				case r.Method == http.MethodGet && r.URL.Path == "/api/v1/scoped-tokens/tok_1":
+					json.NewEncoder(w).Encode(model.ScopedToken{
+						ID:        "tok_1",
+						Label:     "deploy",
+						Scope:     model.ScopedTokenScope{StoreID: &storeID, Repos: []string{"web"}, Permissions: []string{"write"}},
+						CreatedAt: "2026-01-01T00:00:00Z",
+						ExpiresAt: &expires,
+					})
+				case r.Method == http.MethodGet:
+					w.WriteHeader(http.StatusNotFound)
+					w.Write([]byte(`{"error": "not found"}`))
+				case r.Method == http.MethodPost && r.URL.Path == "/api/v1/api-keys":
+					created = r.URL.Path
+					json.NewDecoder(r.Body).Decode(&body)
+					w.Write([]byte(`{"api_key": {"id": "new_1"}, "raw_key": "scraps_newkey"}`))
+				case r.Method == http.MethodPost:
+					created = r.URL.Path
+					json.NewDecoder(r.Body).Decode(&body)
+					json.NewEncoder(w).Encode(model.TokenCreateResponse{ID: "new_1", RawKey: "scraps_newkey"})
+				case r.Method == http.MethodDelete:
+					revoked = r.URL.Path
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer server.Close()
+
+			t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+			t.Setenv("SCRAPS_HOST", server.URL)
+			t.Setenv("SCRAPS_API_KEY", "test-key")
+			t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+			cmd := newTokenRotateCmd()
+			cmd.SetArgs(tt.args)
+			var err error
+			out := captureStdout(t, func() { err = cmd.Execute() })
+			if err != nil {
+				t.Fatalf("token rotate error = %v", err)
+			}
+
+			if created != tt.wantCreate || !reflect.DeepEqual(body, tt.wantBody) {
+				t.Errorf("created %s with %v, want %s with %v", created, body, tt.wantCreate, tt.wantBody)
+			}
+			if revoked != tt.wantRevoked {
+				t.Errorf("revoked %q, want %q", revoked, tt.wantRevoked)
+			}
+
+			var result struct {
+				ID         string `json:"id"`
+				ReplacedID string `json:"replaced_id"`
+				Revoked    bool   `json:"revoked"`
+			}
+			if err := json.Unmarshal([]byte(out), &result); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, out)
+			}
+			if result.ID != "new_1" || result.ReplacedID != tt.args[0] || result.Revoked != (tt.wantRevoked != "") {
+				t.Errorf("result = %+v", result)
+			}
+		})
+	}
+}
+
+func TestFormatExpiresIn(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *string {
+		s := now.Add(d).Format(time.RFC3339)
+		return &s
+	}
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		expiresAt *string
+		want      string
+	}{
+		{name: "no expiry", want: "-"},
+		{name: "expired", expiresAt: at(-time.Hour), want: "! expired"},
+		{name: "seconds", expiresAt: at(30 * time.Second), want: "! < 1 minute"},
+		{name: "minutes", expiresAt: at(5 * time.Minute), want: "! 5 minutes"},
+		{name: "one hour", expiresAt: at(90 * time.Minute), want: "! 1 hour"},
+		{name: "days in window", expiresAt: at(3*24*time.Hour + time.Hour), want: "! 3 days"},
+		{name: "window edge", expiresAt: at(week), want: "! 7 days"},
+		{name: "outside window", expiresAt: at(30 * 24 * time.Hour), want: "30 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatExpiresIn(tt.expiresAt, now, week); got != tt.want {
+				t.Errorf("formatExpiresIn() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenListExpiring(t *testing.T) {
+	soon := time.Now().Add(2 * 24 * time.Hour).Format(time.RFC3339)
+	later := time.Now().Add(60 * 24 * time.Hour).Format(time.RFC3339)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/api-keys":
+			json.NewEncoder(w).Encode([]model.APIKey{{ID: "key_1", Label: "laptop"}})
+		case "/api/v1/scoped-tokens":
+			json.NewEncoder(w).Encode([]model.ScopedToken{
+				{ID: "tok_soon", Label: "deploy", ExpiresAt: &soon},
+				{ID: "tok_later", Label: "ci", ExpiresAt: &later},
+			})
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name:    "all tokens",
+			args:    nil,
+			want:    []string{"key_1", "tok_soon", "! 1 day", "tok_later", "59 days"},
+			notWant: []string{"! 59 days"},
+		},
+		{
+			name:    "expiring",
+			args:    []string{"--expiring", "7d"},
+			want:    []string{"tok_soon"},
+			notWant: []string{"key_1", "tok_later"},
+			wantErr: true,
+		},
+		{
+			name:    "nothing expiring",
+			args:    []string{"--expiring", "1h"},
+			notWant: []string{"tok_soon", "tok_later"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newTokenListCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			var err error
+			out := captureStdout(t, func() { err = cmd.Execute() })
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("token list error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(out, s) {
+					t.Errorf("output missing %q:\n%s", s, out)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(out, s) {
+					t.Errorf("output contains %q:\n%s", s, out)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTokenByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/api-keys/key_1":
+			json.NewEncoder(w).Encode(map[string]any{"api_key": model.APIKey{ID: "key_1"}})
+		case "/api/v1/scoped-tokens/tok_1":
+			json.NewEncoder(w).Encode(model.ScopedToken{ID: "tok_1"})
+		case "/api/v1/api-keys/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": "internal error"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	client, err := api.NewClientFromConfig("")
+	if err != nil {
+		t.Fatalf("NewClientFromConfig() error = %v", err)
+	}
+
+	key, token, err := getTokenByID(client, "key_1")
+	if err != nil || key == nil || key.ID != "key_1" || token != nil {
+		t.Errorf("getTokenByID(key_1) = %v, %v, %v; want the API key", key, token, err)
+	}
+
+	// Not an API key, so it is looked up as a scoped token
+	key, token, err = getTokenByID(client, "tok_1")
+	if err != nil || token == nil || token.ID != "tok_1" || key != nil {
+		t.Errorf("getTokenByID(tok_1) = %v, %v, %v; want the scoped token", key, token, err)
+	}
+
+	_, _, err = getTokenByID(client, "missing")
+	if err == nil || !strings.Contains(err.Error(), "token not found: missing") || !isNotFound(err) {
+		t.Errorf("getTokenByID(missing) error = %v, want token not found wrapping the 404", err)
+	}
+
+	// Errors other than 404 are returned without trying scoped tokens
+	_, _, err = getTokenByID(client, "broken")
+	if err == nil || isNotFound(err) {
+		t.Errorf("getTokenByID(broken) error = %v, want the server error", err)
+	}
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/pkg/version"
+)
+
+const installHint = "Run: curl -fsSL https://scraps.sh/install.sh | sh"
+
+// versionWithCheck returns version info and checks for updates.
+func versionWithCheck() string {
+	result := fmt.Sprintf("scraps version %s (commit %s, built %s)", version.Version, version.Commit, version.Date)
+
+	latest, err := version.CheckLatest()
+	if err != nil {
+		return result
+	}
+
+	if version.IsOutdated(version.Version, latest) {
+		result += fmt.Sprintf("\n\n! Update available: %s → %s", version.Version, latest)
+		result += "\n  " + installHint
+	}
+
+	return result
+}
+
+// versionInfo is the structured output of the version command. Latest and
+// Outdated are only known (non-null) when --check is given.
+type versionInfo struct {
+	Version  string  `json:"version"`
+	Commit   string  `json:"commit"`
+	Date     string  `json:"date"`
+	Latest   *string `json:"latest"`
+	Outdated *bool   `json:"outdated"`
+}
+
+func newVersionCmd() *cobra.Command {
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show version information",
+		Long: `Show version information.
+
+With --check, the latest release is fetched from GitHub to see whether an
+update is available. A daily update notice is also shown after commands run
+in a terminal; disable it with 'scraps config set update_check false'.`,
+		Example: "  scraps version\n  scraps version --check\n  scraps version --check -o json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result := versionInfo{
+				Version: version.Version,
+				Commit:  version.Commit,
+				Date:    version.Date,
+			}
+
+			if check {
+				latest, err := version.CheckLatest()
+				if err != nil {
+					return fmt.Errorf("failed to check for updates: %w", err)
+				}
+				_ = config.RecordUpdateCheck(latest, time.Now())
+
+				outdated := version.IsOutdated(version.Version, latest)
+				result.Latest = &latest
+				result.Outdated = &outdated
+			}
+
+			if isStructuredOutput() {
+				return outputStructured(result)
+			}
+
+			fmt.Printf("Version: %s\n", result.Version)
+			fmt.Printf("Commit:  %s\n", result.Commit)
+			fmt.Printf("Built:   %s\n", result.Date)
+
+			if result.Latest == nil {
+				return nil
+			}
+
+			fmt.Println()
+			if *result.Outdated {
+				warn(fmt.Sprintf("Update available: %s → %s", result.Version, *result.Latest))
+				info(installHint)
+			} else {
+				success(fmt.Sprintf("Up to date (latest release is %s)", *result.Latest))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Check GitHub for a newer release")
+	return cmd
+}
+
+// notifyUpdate prints a one-line notice to stderr when a newer release is
+// available. GitHub is asked at most once a day and the answer is cached in
+// config. Nothing is printed for structured or non-interactive output.
+func notifyUpdate() {
+	if version.Version == "dev" || isStructuredOutput() || !isInteractive() || !config.GetUpdateCheck() {
+		return
+	}
+
+	now := time.Now()
+	latest, due := config.CachedLatestVersion(now)
+	if due {
+		if l, err := version.CheckLatest(); err == nil {
+			latest = l
+		}
+		// Record failures too so an offline machine isn't retried every run
+		_ = config.RecordUpdateCheck(latest, now)
+	}
+
+	if version.IsOutdated(version.Version, latest) {
+		fmt.Fprintf(os.Stderr, "! A new version (v%s) is available\n", latest)
+	}
+}
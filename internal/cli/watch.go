@@ -1,24 +1,46 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/morrisclay/scraps-cli/internal/api"
+	"github.com/morrisclay/scraps-cli/internal/config"
+	"github.com/morrisclay/scraps-cli/internal/model"
 	"github.com/morrisclay/scraps-cli/internal/stream"
+	"github.com/morrisclay/scraps-cli/internal/ws"
 )
 
 func newWatchCmd() *cobra.Command {
-	var branch, path string
+	var opts watchOptions
+	var historySince string
 
 	cmd := &cobra.Command{
 		Use:   "watch <store/repo[:branch]>",
 		Short: "Watch repository events in real-time",
 		Long: `Watch repository events in real-time.
 
+Output formats (--format):
+  pretty  Colored, human-readable lines (default)
+  text    One "HH:MM:SS TYPE summary" line per event
+  json    One compact JSON object per line (NDJSON)
+
+With --output json and no --format, events are written as NDJSON. In the
+text and json formats only events go to stdout; status messages go to
+stderr, so the output can be piped straight into jq.
+
+Events arrive over server-sent events by default. --transport ws uses the
+WebSocket endpoint instead, for servers that only expose that; it does not
+support --path or --last-event.
+
 Examples:
   # Watch all events
   scraps watch mystore/myrepo
@@ -33,13 +55,35 @@ Examples:
   scraps watch mystore/myrepo --path "src/**/*.ts"
 
   # Combine branch and path filters
-  scraps watch mystore/myrepo:main --path "src/**"`,
+  scraps watch mystore/myrepo:main --path "src/**"
+
+  # Resume after a previously seen event
+  scraps watch mystore/myrepo --last-event 1234
+
+  # Only live events, no recent history
+  scraps watch mystore/myrepo --history 0
+
+  # Dump the last hour of activity, then keep watching
+  scraps watch mystore/myrepo --history 200 --history-since 1h
+
+  # Wait for the next event, then exit
+  scraps watch mystore/myrepo:main --once
+
+  # Only show claim and release activity
+  scraps watch mystore/myrepo --claims-only
+
+  # Stream events as NDJSON into a log collector
+  scraps watch mystore/myrepo --format json | tee events.log
+
+  # Use the WebSocket endpoint
+  scraps watch mystore/myrepo:main --transport ws`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
 				return fmt.Errorf("repository reference required\n\nUsage: scraps watch <store/repo[:branch]>\n\nExample: scraps watch mystore/myrepo")
 			}
 			return nil
 		},
+		ValidArgsFunction: completeBranchRef(""),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			store, repo, parsedBranch, err := parseStoreRepoBranch(args[0])
 			if err != nil {
@@ -47,7 +91,38 @@ Examples:
 			}
 
 			if parsedBranch != "" {
-				branch = parsedBranch
+				opts.branch = parsedBranch
+			}
+
+			if opts.count < 0 {
+				return fmt.Errorf("--count must not be negative")
+			}
+			if opts.history < 0 {
+				return fmt.Errorf("--history must not be negative")
+			}
+			if historySince != "" && opts.history == 0 {
+				return fmt.Errorf("--history-since cannot be used with --history 0")
+			}
+			opts.historySince, err = parseTimeBound(historySince, time.Now())
+			if err != nil {
+				return fmt.Errorf("invalid --history-since: %w", err)
+			}
+			if once, _ := cmd.Flags().GetBool("once"); once {
+				opts.count = 1
+			}
+
+			// -o json asks for machine-readable output, which for a stream is NDJSON
+			if !cmd.Flags().Changed("format") && config.GetOutputFormat() == "json" {
+				opts.format = "json"
+			}
+			if !slices.Contains(watchFormats, opts.format) {
+				return fmt.Errorf("invalid format %q (valid: %s)", opts.format, strings.Join(watchFormats, ", "))
+			}
+			if !slices.Contains(watchTransports, opts.transport) {
+				return fmt.Errorf("invalid transport %q (valid: %s)", opts.transport, strings.Join(watchTransports, ", "))
+			}
+			if opts.transport == "ws" && (opts.path != "" || opts.lastEvent != "") {
+				return fmt.Errorf("--path and --last-event are not supported with --transport ws")
 			}
 
 			client, err := api.NewClientFromConfig("")
@@ -55,16 +130,74 @@ Examples:
 				return err
 			}
 
-			return runWatch(client, store, repo, branch, path)
+			return runWatch(client, store, repo, opts)
 		},
 	}
 
-	cmd.Flags().StringVarP(&branch, "branch", "b", "", "Filter to specific branch")
-	cmd.Flags().StringVarP(&path, "path", "p", "", "Filter to specific path or glob pattern (e.g., \"src/**/*.ts\")")
+	cmd.Flags().StringVarP(&opts.branch, "branch", "b", "", "Filter to specific branch")
+	cmd.Flags().StringVarP(&opts.path, "path", "p", "", "Filter to specific path or glob pattern (e.g., \"src/**/*.ts\")")
+	cmd.Flags().StringVar(&opts.lastEvent, "last-event", "", "Resume from event ID")
+	cmd.Flags().IntVar(&opts.history, "history", defaultWatchHistory, "Number of recent events to show before live ones (0 for none)")
+	cmd.Flags().StringVar(&historySince, "history-since", "", "Only show recent events after this time (RFC3339 or relative, e.g. 1h, 7d)")
+	cmd.Flags().StringVar(&opts.format, "format", "pretty", "Event format (pretty, text, json)")
+	cmd.Flags().StringVar(&opts.transport, "transport", "sse", "Event transport (sse, ws)")
+	cmd.Flags().Bool("once", false, "Exit after the first live event")
+	cmd.Flags().IntVar(&opts.count, "count", 0, "Exit after N live events")
+	cmd.MarkFlagsMutuallyExclusive("once", "count")
+	cmd.Flags().BoolVar(&opts.claimsOnly, "claims-only", false, "Only show claim and release events")
+	cmd.Flags().BoolVar(&opts.noClaims, "no-claims", false, "Hide claim and release events")
+	cmd.MarkFlagsMutuallyExclusive("claims-only", "no-claims")
 
 	return cmd
 }
 
+// defaultWatchHistory is how many recent events watch shows by default.
+const defaultWatchHistory = 20
+
+// watchFormats lists the supported --format values for watch.
+var watchFormats = []string{"pretty", "text", "json"}
+
+// watchTransports lists the supported --transport values for watch.
+var watchTransports = []string{"sse", "ws"}
+
+// watchOptions holds the flags for the watch command.
+type watchOptions struct {
+	branch       string
+	path         string
+	lastEvent    string
+	format       string
+	transport    string
+	count        int       // exit after this many live events; 0 means never
+	history      int       // recent events to show before live ones; 0 means none
+	historySince time.Time // only show recent events after this; zero for all
+	claimsOnly   bool
+	noClaims     bool
+}
+
+// include reports whether an event passes the claim filters.
+func (o watchOptions) include(event model.Event) bool {
+	switch {
+	case o.claimsOnly:
+		return isClaimEvent(event)
+	case o.noClaims:
+		return !isClaimEvent(event)
+	}
+	return true
+}
+
+// isClaimEvent reports whether an event describes claim or release activity.
+func isClaimEvent(event model.Event) bool {
+	switch event.EventType() {
+	case "agent_claim", "agent_release", "activity":
+		return true
+	}
+	return false
+}
+
+// watchMaxReconnects is how many times the stream client retries a dropped
+// connection before falling back to the outer reconnect loop.
+const watchMaxReconnects = 5
+
 // streamState tracks in-progress file streaming for cursor updates
 type streamState struct {
 	lastChunkAgent string
@@ -72,72 +205,248 @@ type streamState struct {
 	hasChunkLine   bool
 }
 
-func runWatch(client *api.Client, store, repo, branch, path string) error {
-	info(fmt.Sprintf("Watching %s/%s", store, repo))
-	if branch != "" {
-		fmt.Printf("Branch: %s\n", branch)
+func runWatch(client *api.Client, store, repo string, opts watchOptions) error {
+	// Keep stdout clean for machine-readable formats
+	var status io.Writer = os.Stdout
+	switch {
+	case quiet:
+		status = io.Discard
+	case opts.format != "pretty":
+		status = os.Stderr
 	}
-	if path != "" {
-		fmt.Printf("Path: %s\n", path)
+
+	fmt.Fprintf(status, "→ Watching %s/%s\n", store, repo)
+	if opts.branch != "" {
+		fmt.Fprintf(status, "Branch: %s\n", opts.branch)
+	}
+	if opts.path != "" {
+		fmt.Fprintf(status, "Path: %s\n", opts.path)
 	}
 
-	// Fetch and display recent historical events
-	events, err := client.GetRecentStreamEvents(store, repo, 20)
-	if err != nil {
-		errorf("Failed to fetch historical events: %v", err)
-	} else if len(events) > 0 {
-		fmt.Printf("\n--- Recent events (%d) ---\n", len(events))
-		for i := len(events) - 1; i >= 0; i-- {
-			printEvent(events[i], nil) // No cursor updates for historical
+	lastEvent := opts.lastEvent
+	if lastEvent != "" {
+		// The server replays everything after lastEvent, so skip history
+		fmt.Fprintf(status, "Resuming after event %s\n", lastEvent)
+	} else if opts.history > 0 {
+		// Fetch and display recent historical events
+		events, err := client.GetRecentStreamEvents(store, repo, opts.history, opts.historySince)
+		// The server may ignore since, so filter locally too (nil-safe on error)
+		events = filterEventsSince(events, opts.historySince)
+		if err != nil {
+			errorf("Failed to fetch historical events: %v", err)
+		} else if len(events) > 0 {
+			fmt.Fprintf(status, "\n--- Recent events (%d) ---\n", len(events))
+			for i := len(events) - 1; i >= 0; i-- {
+				data, err := json.Marshal(events[i])
+				if err != nil {
+					continue
+				}
+				event, err := model.DecodeEvent(data)
+				if err != nil || !opts.include(event) {
+					continue
+				}
+				emitEvent(opts.format, data, event, nil) // No cursor updates for historical
+			}
+			fmt.Fprintln(status, "--- Live events ---")
+		} else {
+			fmt.Fprintln(status, "(no recent events)")
 		}
-		fmt.Println("--- Live events ---")
-	} else {
-		fmt.Println("(no recent events)")
 	}
 
-	fmt.Println("Press Ctrl+C to stop")
-	fmt.Println()
-
-	opts := &api.StreamOptions{Branch: branch, Path: path}
-	streamURL := client.BuildStreamURL(store, repo, opts)
+	fmt.Fprintln(status, "Press Ctrl+C to stop")
+	fmt.Fprintln(status)
 
 	state := &streamState{}
 
+	// Count live events and stop once opts.count is reached
+	seen := 0
+	stop := make(chan struct{})
+
 	// Auto-reconnect loop
 	for {
-		streamClient := stream.NewClient(streamURL, client.APIKey())
+		var handlers eventHandlers
+		handlers.OnReconnect = func(attempt int, delay time.Duration) {
+			if state.hasChunkLine {
+				fmt.Println()
+				state.hasChunkLine = false
+			}
+			fmt.Fprintf(status, "! Stream dropped, reconnecting in %s (attempt %d/%d)...\n", delay, attempt, watchMaxReconnects)
+		}
 
-		streamClient.OnMessage = func(data []byte) {
-			var msg map[string]any
-			if json.Unmarshal(data, &msg) == nil {
-				printEvent(msg, state)
-			} else {
-				fmt.Println(string(data))
+		handlers.OnMessage = func(data []byte) {
+			if opts.count > 0 && seen >= opts.count {
+				return
+			}
+
+			event, err := model.DecodeEvent(data)
+			if err != nil {
+				// Not an event; keep it out of machine-readable output and
+				// don't count it towards --count
+				fmt.Fprintln(status, string(data))
+				return
+			}
+			if !opts.include(event) {
+				// Filtered events don't count towards --count
+				return
+			}
+			emitEvent(opts.format, data, event, state)
+
+			seen++
+			if opts.count > 0 && seen == opts.count {
+				close(stop)
 			}
 		}
 
-		streamClient.OnError = func(err error) {
+		handlers.OnError = func(err error) {
 			// Don't print EOF errors, just reconnect silently
 			if err.Error() != "EOF" {
 				errorf("Stream error: %v", err)
 			}
 		}
 
+		// Resume from the last event seen on the previous connection
+		streamClient := newEventStream(client, store, repo, opts, lastEvent, handlers)
 		if err := streamClient.Connect(); err != nil {
 			errorf("Connection failed: %v, retrying...", err)
 			time.Sleep(2 * time.Second)
 			continue
 		}
 
-		// Wait for connection to close
-		<-streamClient.Done()
+		// Wait for connection to close or the event count to be reached
+		select {
+		case <-streamClient.Done():
+		case <-stop:
+			streamClient.Close()
+			<-streamClient.Done()
+			if state.hasChunkLine {
+				fmt.Println()
+			}
+			return nil
+		}
 		streamClient.Close()
 
+		if id := streamClient.LastEventID(); id != "" {
+			lastEvent = id
+		}
+
 		// Reconnect after a brief pause
 		time.Sleep(500 * time.Millisecond)
 	}
 }
 
+// eventStream is a live connection to a repository's events. It is
+// implemented over server-sent events and over WebSocket.
+type eventStream interface {
+	Connect() error
+	Close() error
+	Done() <-chan struct{}
+	// LastEventID is the ID to resume after, or "" if unknown.
+	LastEventID() string
+}
+
+// eventHandlers are the callbacks an eventStream delivers to.
+type eventHandlers struct {
+	OnMessage func([]byte)
+	OnError   func(error)
+	// OnReconnect is only called by the SSE transport, which retries a
+	// dropped connection itself.
+	OnReconnect func(attempt int, delay time.Duration)
+}
+
+// newEventStream returns an unconnected stream of the repository's events
+// over the transport in opts, resuming after lastEvent where supported.
+func newEventStream(client *api.Client, store, repo string, opts watchOptions, lastEvent string, h eventHandlers) eventStream {
+	if opts.transport == "ws" {
+		c := ws.NewClient(client.BuildWebSocketURL(store, repo, opts.branch), client.APIKey())
+		c.OnMessage = h.OnMessage
+		c.OnError = h.OnError
+		return wsEventStream{c}
+	}
+
+	// Resume only via the Last-Event-ID header, which the stream client
+	// updates as events arrive. A last_event_id in the URL would stay fixed
+	// and replay old events after every automatic reconnect.
+	streamOpts := &api.StreamOptions{Branch: opts.branch, Path: opts.path}
+	c := stream.NewClient(client.BuildStreamURL(store, repo, streamOpts), client.APIKey()).
+		WithReconnect(watchMaxReconnects).
+		WithLastEventID(lastEvent)
+	c.OnMessage = h.OnMessage
+	c.OnError = h.OnError
+	c.OnReconnect = h.OnReconnect
+	return c
+}
+
+// wsEventStream adapts ws.Client, whose events carry no resumable IDs.
+type wsEventStream struct {
+	*ws.Client
+}
+
+func (wsEventStream) LastEventID() string { return "" }
+
+// filterEventsSince keeps raw events whose timestamp is at or after since.
+// A zero since keeps everything; otherwise events without a parseable
+// timestamp are dropped because they cannot be placed in the window.
+func filterEventsSince(events []map[string]interface{}, since time.Time) []map[string]interface{} {
+	if since.IsZero() {
+		return events
+	}
+
+	var filtered []map[string]interface{}
+	for _, e := range events {
+		ts, _ := e["timestamp"].(string)
+		t, err := time.Parse(time.RFC3339, ts)
+		if err != nil || t.Before(since) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// emitEvent writes an event to stdout in the given watch format.
+func emitEvent(format string, data []byte, event model.Event, state *streamState) {
+	switch format {
+	case "json":
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, data); err != nil {
+			buf.Reset()
+			buf.Write(data)
+		}
+		buf.WriteByte('\n')
+		// os.Stdout is unbuffered, so each event reaches pipes immediately
+		os.Stdout.Write(buf.Bytes())
+	case "text":
+		fmt.Println(formatEventText(data, event))
+	default:
+		printEvent(event, state)
+	}
+}
+
+// formatEventText renders an event as "HH:MM:SS TYPE summary", using the
+// event's timestamp when present and the current time otherwise.
+func formatEventText(data []byte, event model.Event) string {
+	ts := time.Now()
+	var meta struct {
+		Timestamp string `json:"timestamp"`
+	}
+	if json.Unmarshal(data, &meta) == nil && meta.Timestamp != "" {
+		if t, err := time.Parse(time.RFC3339, meta.Timestamp); err == nil {
+			ts = t.Local()
+		}
+	}
+
+	summary, ok := eventSummary(event)
+	if !ok {
+		var buf bytes.Buffer
+		if json.Compact(&buf, data) == nil {
+			summary = buf.String()
+		} else {
+			summary = string(data)
+		}
+	}
+	return fmt.Sprintf("%s %s %s", ts.Format("15:04:05"), event.EventType(), summary)
+}
+
 // ANSI color codes
 const (
 	colorReset   = "\033[0m"
@@ -172,32 +481,80 @@ func coloredType(eventType string) string {
 	default:
 		color = colorReset
 	}
-	return fmt.Sprintf("%s[%s]%s", color, eventType, colorReset)
+	return colorize(color, "["+eventType+"]")
 }
 
-func printEvent(event map[string]any, state *streamState) {
-	eventType, _ := event["type"].(string)
-	agentID, _ := event["agent_id"].(string)
+// eventSummary returns a one-line description of an event, without its
+// type tag. It returns false for events with no compact form.
+func eventSummary(event model.Event) (string, bool) {
+	switch e := event.(type) {
+	case model.CommitEvent:
+		return fmt.Sprintf("%s %s", shortSHA(e.SHA), e.Message), true
+	case model.BranchEvent:
+		name := e.Branch
+		if name == "" {
+			name = e.Name
+		}
+		return fmt.Sprintf("%s %s", name, shortSHA(e.NewSHA)), true
+	case model.AgentClaimEvent:
+		verb := "claimed"
+		if e.Type == "agent_release" {
+			verb = "released"
+		}
+		return fmt.Sprintf("%s %s %v", e.AgentID, verb, e.Patterns), true
+	case model.ActivityEvent:
+		verb := "claimed"
+		if e.Activity.Type == "release" {
+			verb = "released"
+		}
+		return fmt.Sprintf("%s %s %v", e.Activity.AgentID, verb, e.Activity.Patterns), true
+	case model.UnknownEvent:
+		agentID, _ := e.Fields["agent_id"].(string)
+		switch e.Type {
+		case "agent_join":
+			role, _ := e.Fields["role"].(string)
+			return fmt.Sprintf("%s joined (%s)", agentID, role), true
+		case "agent_leave":
+			role, _ := e.Fields["role"].(string)
+			return fmt.Sprintf("%s left (%s)", agentID, role), true
+		case "file_write":
+			path, _ := e.Fields["path"].(string)
+			return fmt.Sprintf("%s wrote %s", agentID, path), true
+		case "file_chunk":
+			path, _ := e.Fields["path"].(string)
+			version, _ := e.Fields["version"].(float64)
+			return fmt.Sprintf("%s streaming %s (%d chars)", agentID, path, int(version)), true
+		case "error":
+			errMsg, _ := e.Fields["error"].(string)
+			return fmt.Sprintf("%s: %s", agentID, errMsg), true
+		}
+	}
+	return "", false
+}
+
+func printEvent(event model.Event, state *streamState) {
+	eventType := event.EventType()
 	tag := coloredType(eventType)
+	summary, ok := eventSummary(event)
 
 	// Handle file_chunk specially for cursor updates
-	if eventType == "file_chunk" {
-		path, _ := event["path"].(string)
-		version, _ := event["version"].(float64)
-
+	if raw, isRaw := event.(model.UnknownEvent); isRaw && eventType == "file_chunk" {
 		if state != nil {
+			agentID, _ := raw.Fields["agent_id"].(string)
+			path, _ := raw.Fields["path"].(string)
+
 			// Check if this is a continuation of the same stream
 			sameStream := state.lastChunkAgent == agentID && state.lastChunkFile == path
 
 			if sameStream && state.hasChunkLine {
 				// Update in place with carriage return
-				fmt.Printf("\r  %s %s streaming %s (%d chars)    ", tag, agentID, path, int(version))
+				fmt.Printf("\r  %s %s    ", tag, summary)
 			} else {
 				// New stream or first chunk - finish previous line if any
 				if state.hasChunkLine {
 					fmt.Println() // Commit previous line
 				}
-				fmt.Printf("  %s %s streaming %s (%d chars)", tag, agentID, path, int(version))
+				fmt.Printf("  %s %s", tag, summary)
 			}
 
 			state.lastChunkAgent = agentID
@@ -205,7 +562,7 @@ func printEvent(event map[string]any, state *streamState) {
 			state.hasChunkLine = true
 		} else {
 			// No state (historical) - just print normally
-			fmt.Printf("  %s %s streaming %s (%d chars)\n", tag, agentID, path, int(version))
+			fmt.Printf("  %s %s\n", tag, summary)
 		}
 		return
 	}
@@ -219,35 +576,14 @@ func printEvent(event map[string]any, state *streamState) {
 	}
 
 	// Compact format for common events
-	switch eventType {
-	case "agent_join":
-		role, _ := event["role"].(string)
-		fmt.Printf("  %s %s joined (%s)\n", tag, agentID, role)
-	case "agent_leave":
-		role, _ := event["role"].(string)
-		fmt.Printf("  %s %s left (%s)\n", tag, agentID, role)
-	case "agent_claim":
-		patterns, _ := event["patterns"].([]any)
-		fmt.Printf("  %s %s claimed %v\n", tag, agentID, patterns)
-	case "agent_release":
-		patterns, _ := event["patterns"].([]any)
-		fmt.Printf("  %s %s released %v\n", tag, agentID, patterns)
-	case "file_write":
-		path, _ := event["path"].(string)
-		fmt.Printf("  %s %s wrote %s\n", tag, agentID, path)
-	case "commit":
-		sha, _ := event["sha"].(string)
-		msg, _ := event["message"].(string)
-		if len(sha) > 7 {
-			sha = sha[:7]
-		}
-		fmt.Printf("  %s %s %s\n", tag, sha, msg)
-	case "error":
-		errMsg, _ := event["error"].(string)
-		fmt.Printf("  %s %s: %s\n", tag, agentID, errMsg)
-	default:
-		// Full JSON for unknown events
-		formatted, _ := json.MarshalIndent(event, "  ", "  ")
+	if ok {
+		fmt.Printf("  %s %s\n", tag, summary)
+		return
+	}
+
+	// Full JSON for unknown events
+	if raw, isRaw := event.(model.UnknownEvent); isRaw {
+		formatted, _ := json.MarshalIndent(raw.Fields, "  ", "  ")
 		fmt.Println(string(formatted))
 	}
 }
@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/morrisclay/scraps-cli/internal/model"
+)
+
+func TestFormatEventText(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "commit",
+			data: `{"type":"commit","sha":"abcdef1234","message":"Fix bug","timestamp":"2024-03-15T10:30:00Z"}`,
+			want: "commit abcdef1 Fix bug",
+		},
+		{
+			name: "claim",
+			data: `{"type":"agent_claim","agent_id":"a1","patterns":["src/**"]}`,
+			want: "agent_claim a1 claimed [src/**]",
+		},
+		{
+			name: "unknown",
+			data: `{"type":"custom", "value": 1}`,
+			want: `custom {"type":"custom","value":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := model.DecodeEvent([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("DecodeEvent() error = %v", err)
+			}
+			got := formatEventText([]byte(tt.data), event)
+			// Skip the HH:MM:SS prefix, which depends on the local time zone
+			if len(got) < 9 || got[9:] != tt.want {
+				t.Errorf("formatEventText() = %q, want suffix %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchOptionsInclude(t *testing.T) {
+	claim := model.AgentClaimEvent{Type: "agent_claim"}
+	activity := model.ActivityEvent{Type: "activity"}
+	commit := model.CommitEvent{Type: "commit"}
+
+	tests := []struct {
+		name string
+		opts watchOptions
+		want []bool // claim, activity, commit
+	}{
+		{name: "no filter", opts: watchOptions{}, want: []bool{true, true, true}},
+		{name: "claims only", opts: watchOptions{claimsOnly: true}, want: []bool{true, true, false}},
+		{name: "no claims", opts: watchOptions{noClaims: true}, want: []bool{false, false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, event := range []model.Event{claim, activity, commit} {
+				if got := tt.opts.include(event); got != tt.want[i] {
+					t.Errorf("include(%s) = %v, want %v", event.EventType(), got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWatchOutputJSONIsNDJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/stores/s/repos/r/streams/events":
+			// Newest first, as the server returns them
+			fmt.Fprint(w, `{"events": [{"type": "commit", "sha": "bbb"}, {"type": "commit", "sha": "aaa"}]}`)
+		case "/api/v1/stores/s/repos/r/streams/events/live":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: not json\n\n")
+			fmt.Fprint(w, "id: 3\ndata: {\"type\": \"commit\", \"sha\": \"ccc\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "json")
+
+	cmd := newWatchCmd()
+	// The non-JSON message doesn't count as a live event
+	cmd.SetArgs([]string{"s/r", "--count", "1"})
+	var err error
+	out := captureStdout(t, func() { err = cmd.Execute() })
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	var shas []string
+	for _, line := range lines {
+		var event struct {
+			SHA string `json:"sha"`
+		}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("stdout line %q is not JSON (output %q)", line, out)
+		}
+		shas = append(shas, event.SHA)
+	}
+	if want := "aaa bbb ccc"; strings.Join(shas, " ") != want {
+		t.Errorf("event order = %v, want %s", shas, want)
+	}
+}
+
+func TestWatchWebSocketTransport(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stores/s/repos/r/ws" || r.URL.Query().Get("branch") != "main" {
+			t.Errorf("unexpected request %s", r.URL)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type": "commit", "sha": "ws1"}`))
+		// Wait for the client to close
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "")
+
+	cmd := newWatchCmd()
+	cmd.SetArgs([]string{"s/r:main", "--transport", "ws", "--history", "0", "--format", "json", "--once"})
+	var err error
+	out := captureStdout(t, func() { err = cmd.Execute() })
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+	if !strings.Contains(out, `"sha":"ws1"`) {
+		t.Errorf("output = %q, want the WebSocket event", out)
+	}
+}
+
+func TestWatchTransportFlags(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"s/r", "--transport", "carrier-pigeon"}, wantErr: "invalid transport"},
+		{args: []string{"s/r", "--transport", "ws", "--path", "src/*"}, wantErr: "not supported with --transport ws"},
+		{args: []string{"s/r", "--transport", "ws", "--last-event", "7"}, wantErr: "not supported with --transport ws"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			cmd := newWatchCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterEventsSince(t *testing.T) {
+	events := []map[string]interface{}{
+		{"type": "commit", "sha": "new", "timestamp": "2024-03-15T12:00:00Z"},
+		{"type": "commit", "sha": "old", "timestamp": "2024-03-15T08:00:00Z"},
+		{"type": "commit", "sha": "undated"},
+	}
+
+	tests := []struct {
+		name  string
+		since time.Time
+		want  []string
+	}{
+		{name: "no bound", want: []string{"new", "old", "undated"}},
+		{name: "bound", since: time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC), want: []string{"new"}},
+		{name: "exact", since: time.Date(2024, 3, 15, 8, 0, 0, 0, time.UTC), want: []string{"new", "old"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range filterEventsSince(events, tt.since) {
+				got = append(got, e["sha"].(string))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("filterEventsSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchOnceSkipsNonEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: keepalive\n\n")
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, "data: {\"type\": \"commit\", \"sha\": \"abc\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "")
+
+	cmd := newWatchCmd()
+	cmd.SetArgs([]string{"s/r", "--history", "0", "--format", "json", "--once"})
+	var err error
+	out := captureStdout(t, func() { err = cmd.Execute() })
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+	if !strings.Contains(out, `"sha":"abc"`) {
+		t.Errorf("output = %q, want the event after the keepalive", out)
+	}
+}
+
+func TestWatchQuiet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"type\": \"commit\", \"sha\": \"abc\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "")
+
+	quiet = true
+	defer func() { quiet = false }()
+
+	cmd := newWatchCmd()
+	cmd.SetArgs([]string{"s/r:main", "--history", "0", "--format", "pretty", "--once"})
+	var err error
+	out := captureStdout(t, func() { err = cmd.Execute() })
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+	for _, status := range []string{"Watching", "Branch:", "Ctrl+C"} {
+		if strings.Contains(out, status) {
+			t.Errorf("output = %q, want no %q status line with --quiet", out, status)
+		}
+	}
+	if !strings.Contains(out, "abc") {
+		t.Errorf("output = %q, want the event", out)
+	}
+}
+
+func TestWatchReconnectResumesFromLatestEvent(t *testing.T) {
+	var connects atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("last_event_id") {
+			t.Errorf("URL %s carries last_event_id; want the header only", r.URL)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch connects.Add(1) {
+		case 1:
+			if got := r.Header.Get("Last-Event-ID"); got != "1" {
+				t.Errorf("first Last-Event-ID = %q, want 1", got)
+			}
+			// Send one event, then drop the connection
+			fmt.Fprint(w, "id: 2\ndata: {\"type\": \"commit\", \"sha\": \"bbb\"}\n\n")
+			w.(http.Flusher).Flush()
+		default:
+			if got := r.Header.Get("Last-Event-ID"); got != "2" {
+				t.Errorf("reconnect Last-Event-ID = %q, want 2", got)
+			}
+			fmt.Fprint(w, "id: 3\ndata: {\"type\": \"commit\", \"sha\": \"ccc\"}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+	t.Setenv("SCRAPS_HOST", server.URL)
+	t.Setenv("SCRAPS_API_KEY", "test-key")
+	t.Setenv("SCRAPS_OUTPUT_FORMAT", "")
+
+	cmd := newWatchCmd()
+	cmd.SetArgs([]string{"s/r", "--last-event", "1", "--format", "json", "--count", "2"})
+	var err error
+	out := captureStdout(t, func() {
+		captureStderr(t, func() { err = cmd.Execute() })
+	})
+	if err != nil {
+		t.Fatalf("watch error = %v", err)
+	}
+	if !strings.Contains(out, `"sha":"bbb"`) || !strings.Contains(out, `"sha":"ccc"`) {
+		t.Errorf("output = %q, want both events", out)
+	}
+}
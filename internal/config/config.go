@@ -3,8 +3,14 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -12,16 +18,54 @@ const (
 	DefaultHost = "https://api.scraps.sh"
 	// DefaultOutputFormat is the default output format.
 	DefaultOutputFormat = "table"
+	// DefaultRequestTimeoutSeconds is the default time to wait for response headers.
+	DefaultRequestTimeoutSeconds = 30
+	// DefaultCacheTTLSeconds is how long store and repo listings are cached.
+	DefaultCacheTTLSeconds = 30
 )
 
+// OutputFormats lists the accepted output formats.
+var OutputFormats = []string{"table", "json", "yaml", "csv", "tsv"}
+
+// IsValidOutputFormat reports whether format is one of OutputFormats.
+func IsValidOutputFormat(format string) bool {
+	for _, f := range OutputFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
 // Config represents the CLI configuration.
 type Config struct {
-	DefaultHost  string `json:"default_host"`
-	OutputFormat string `json:"output_format"`
+	DefaultHost           string `json:"default_host"`
+	OutputFormat          string `json:"output_format"`
+	RequestTimeoutSeconds int    `json:"request_timeout_seconds"`
+	AgentID               string `json:"agent_id,omitempty"`
+	CredentialStore       string `json:"credential_store,omitempty"`
+	MaskSecrets           bool   `json:"mask_secrets,omitempty"`
+	RelativeDates         bool   `json:"relative_dates,omitempty"`
+	UpdateCheck           *bool  `json:"update_check,omitempty"`
+	CacheTTLSeconds       *int   `json:"cache_ttl_seconds,omitempty"`
+	RequireTypedConfirm   bool   `json:"require_typed_confirmation,omitempty"`
+	DefaultStore          string `json:"default_store,omitempty"`
+
+	// Update check cache, maintained by the CLI rather than the user
+	LastUpdateCheck string `json:"last_update_check,omitempty"`
+	LatestVersion   string `json:"latest_version,omitempty"`
 }
 
-// configDir returns the path to the ~/.scraps directory.
+// updateCheckInterval is how often the CLI looks for a new release.
+const updateCheckInterval = 24 * time.Hour
+
+// configDir returns the path to the configuration directory.
+// SCRAPS_CONFIG_DIR takes precedence when set; otherwise ~/.scraps is used.
+// Both config.json and credentials.json live in this directory.
 func configDir() (string, error) {
+	if dir := os.Getenv("SCRAPS_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -47,6 +91,28 @@ func configPath() (string, error) {
 	return filepath.Join(dir, "config.json"), nil
 }
 
+// ConfigPath returns the path to config.json.
+func ConfigPath() (string, error) {
+	return configPath()
+}
+
+// EnsureConfigFile writes config.json with default values if it does not
+// exist yet, and returns its path.
+func EnsureConfigFile() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil || !os.IsNotExist(err) {
+		return path, err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return path, SaveConfig(cfg)
+}
+
 // LoadConfig loads the configuration from disk, creating defaults if necessary.
 func LoadConfig() (*Config, error) {
 	path, err := configPath()
@@ -58,15 +124,16 @@ func LoadConfig() (*Config, error) {
 	if os.IsNotExist(err) {
 		// Return default config
 		return &Config{
-			DefaultHost:  DefaultHost,
-			OutputFormat: DefaultOutputFormat,
+			DefaultHost:           DefaultHost,
+			OutputFormat:          DefaultOutputFormat,
+			RequestTimeoutSeconds: DefaultRequestTimeoutSeconds,
 		}, nil
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	var cfg Config
+	cfg := Config{RequestTimeoutSeconds: DefaultRequestTimeoutSeconds}
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
@@ -127,13 +194,153 @@ func GetOutputFormat() string {
 	return cfg.OutputFormat
 }
 
-// SetHost updates the default host in config.
+// RequestTimeout returns how long requests wait to connect and receive
+// response headers. A zero duration means no timeout.
+func (c *Config) RequestTimeout() time.Duration {
+	return time.Duration(c.RequestTimeoutSeconds) * time.Second
+}
+
+// CacheTTL returns how long store and repo listings are cached within a
+// single process. A zero duration disables the cache.
+func (c *Config) CacheTTL() time.Duration {
+	if c.CacheTTLSeconds == nil {
+		return DefaultCacheTTLSeconds * time.Second
+	}
+	return time.Duration(*c.CacheTTLSeconds) * time.Second
+}
+
+// GetDefaultStore returns the store that bare repository names resolve
+// against, or "" if none is set.
+func GetDefaultStore() string {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return ""
+	}
+	return cfg.DefaultStore
+}
+
+// GetRequireTypedConfirmation reports whether deleting a store or repo
+// requires typing its name rather than a yes/no confirmation.
+func GetRequireTypedConfirmation() bool {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.RequireTypedConfirm
+}
+
+// GetMaskSecrets reports whether API keys should be masked in output.
+func GetMaskSecrets() bool {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.MaskSecrets
+}
+
+// GetRelativeDates reports whether table dates should be shown relative to
+// now, such as "3 days ago".
+func GetRelativeDates() bool {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return false
+	}
+	return cfg.RelativeDates
+}
+
+// GetUpdateCheck reports whether the daily update notification is enabled.
+// It is on unless update_check is set to false.
+func GetUpdateCheck() bool {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return true
+	}
+	return cfg.UpdateCheck == nil || *cfg.UpdateCheck
+}
+
+// CachedLatestVersion returns the latest release seen by the last update
+// check, and whether that check is older than a day and should be repeated.
+func CachedLatestVersion(now time.Time) (latest string, due bool) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return "", true
+	}
+	last, err := time.Parse(time.RFC3339, cfg.LastUpdateCheck)
+	if err != nil {
+		return cfg.LatestVersion, true
+	}
+	return cfg.LatestVersion, now.Sub(last) >= updateCheckInterval
+}
+
+// RecordUpdateCheck caches the result of an update check.
+func RecordUpdateCheck(latest string, now time.Time) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	cfg.LastUpdateCheck = now.UTC().Format(time.RFC3339)
+	cfg.LatestVersion = latest
+	return SaveConfig(cfg)
+}
+
+// GetAgentID returns the persisted agent identity used for claims,
+// generating and saving a new UUID on first use.
+func GetAgentID() (string, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if cfg.AgentID != "" {
+		return cfg.AgentID, nil
+	}
+	cfg.AgentID = uuid.New().String()
+	if err := SaveConfig(cfg); err != nil {
+		return "", err
+	}
+	return cfg.AgentID, nil
+}
+
+// NormalizeHost cleans up a user-supplied host URL: https:// is assumed when
+// no scheme is given and trailing slashes are removed. Hosts that are not
+// http or https URLs with a hostname are rejected.
+func NormalizeHost(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return "", fmt.Errorf("host cannot be empty")
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %v", host, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid host %q: scheme must be http or https", host)
+	}
+	if u.Host == "" || u.Hostname() == "" {
+		return "", fmt.Errorf("invalid host %q: missing hostname", host)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid host %q: must not contain a query or fragment", host)
+	}
+
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// SetHost normalizes host and updates the default host in config.
 func SetHost(host string) error {
+	host, err := NormalizeHost(host)
+	if err != nil {
+		return err
+	}
 	cfg, err := LoadConfig()
 	if err != nil {
 		cfg = &Config{
-			DefaultHost:  DefaultHost,
-			OutputFormat: DefaultOutputFormat,
+			DefaultHost:           DefaultHost,
+			OutputFormat:          DefaultOutputFormat,
+			RequestTimeoutSeconds: DefaultRequestTimeoutSeconds,
 		}
 	}
 	cfg.DefaultHost = host
@@ -142,11 +349,15 @@ func SetHost(host string) error {
 
 // SetOutputFormat updates the output format in config.
 func SetOutputFormat(format string) error {
+	if !IsValidOutputFormat(format) {
+		return fmt.Errorf("output format must be one of: %s", strings.Join(OutputFormats, ", "))
+	}
 	cfg, err := LoadConfig()
 	if err != nil {
 		cfg = &Config{
-			DefaultHost:  DefaultHost,
-			OutputFormat: DefaultOutputFormat,
+			DefaultHost:           DefaultHost,
+			OutputFormat:          DefaultOutputFormat,
+			RequestTimeoutSeconds: DefaultRequestTimeoutSeconds,
 		}
 	}
 	cfg.OutputFormat = format
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestLoadConfigDefaults(t *testing.T) {
@@ -96,3 +97,175 @@ func TestSetOutputFormat(t *testing.T) {
 		t.Errorf("GetOutputFormat() = %v, want %v", got, "json")
 	}
 }
+
+func TestSetOutputFormatYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	if err := SetOutputFormat("yaml"); err != nil {
+		t.Fatalf("SetOutputFormat() error = %v", err)
+	}
+
+	if got := GetOutputFormat(); got != "yaml" {
+		t.Errorf("GetOutputFormat() = %v, want %v", got, "yaml")
+	}
+}
+
+func TestSetOutputFormatInvalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	if err := SetOutputFormat("xml"); err == nil {
+		t.Error("SetOutputFormat(\"xml\") error = nil, want error")
+	}
+}
+
+func TestConfigDirOverride(t *testing.T) {
+	tmpHome := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", originalHome)
+
+	overrideDir := filepath.Join(t.TempDir(), "isolated")
+	t.Setenv("SCRAPS_CONFIG_DIR", overrideDir)
+
+	if err := SetHost("https://isolated.example.com"); err != nil {
+		t.Fatalf("SetHost() error = %v", err)
+	}
+	if err := SetCredential("https://isolated.example.com", Credential{APIKey: "key"}); err != nil {
+		t.Fatalf("SetCredential() error = %v", err)
+	}
+
+	// Directory and files are created with restrictive permissions
+	dirInfo, err := os.Stat(overrideDir)
+	if err != nil {
+		t.Fatalf("Config dir not created: %v", err)
+	}
+	if dirInfo.Mode().Perm() != 0700 {
+		t.Errorf("Config dir permissions = %v, want %v", dirInfo.Mode().Perm(), 0700)
+	}
+	for _, name := range []string{"config.json", "credentials.json"} {
+		info, err := os.Stat(filepath.Join(overrideDir, name))
+		if err != nil {
+			t.Fatalf("%s not created: %v", name, err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("%s permissions = %v, want %v", name, info.Mode().Perm(), 0600)
+		}
+	}
+
+	// Nothing is written under $HOME/.scraps
+	if _, err := os.Stat(filepath.Join(tmpHome, ".scraps")); !os.IsNotExist(err) {
+		t.Errorf("Expected %s/.scraps to not exist, got err = %v", tmpHome, err)
+	}
+}
+
+func TestConfigDirIsolation(t *testing.T) {
+	tmpHome := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpHome)
+	defer os.Setenv("HOME", originalHome)
+
+	dirA := t.TempDir()
+	dirB := t.TempDir()
+
+	t.Setenv("SCRAPS_CONFIG_DIR", dirA)
+	if err := SetOutputFormat("json"); err != nil {
+		t.Fatalf("SetOutputFormat() error = %v", err)
+	}
+
+	t.Setenv("SCRAPS_CONFIG_DIR", dirB)
+	if got := GetOutputFormat(); got != DefaultOutputFormat {
+		t.Errorf("GetOutputFormat() in dirB = %v, want %v", got, DefaultOutputFormat)
+	}
+
+	t.Setenv("SCRAPS_CONFIG_DIR", dirA)
+	if got := GetOutputFormat(); got != "json" {
+		t.Errorf("GetOutputFormat() in dirA = %v, want json", got)
+	}
+}
+
+func TestGetAgentIDPersists(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	first, err := GetAgentID()
+	if err != nil {
+		t.Fatalf("GetAgentID() error = %v", err)
+	}
+	if first == "" {
+		t.Fatal("GetAgentID() returned empty ID")
+	}
+
+	second, err := GetAgentID()
+	if err != nil {
+		t.Fatalf("GetAgentID() error = %v", err)
+	}
+	if second != first {
+		t.Errorf("GetAgentID() = %v on second call, want %v", second, first)
+	}
+}
+
+func TestNormalizeHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		want    string
+		wantErr bool
+	}{
+		{host: "api.scraps.sh", want: "https://api.scraps.sh"},
+		{host: "https://api.scraps.sh/", want: "https://api.scraps.sh"},
+		{host: "https://api.scraps.sh///", want: "https://api.scraps.sh"},
+		{host: "http://localhost:8080", want: "http://localhost:8080"},
+		{host: "localhost:8080/", want: "https://localhost:8080"},
+		{host: "  https://example.com/base/ ", want: "https://example.com/base"},
+		{host: "", wantErr: true},
+		{host: "ftp://example.com", wantErr: true},
+		{host: "https://", wantErr: true},
+		{host: "https://example.com?x=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			got, err := NormalizeHost(tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeHost(%q) error = %v, wantErr %v", tt.host, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeHost(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateCheckCache(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if _, due := CachedLatestVersion(now); !due {
+		t.Error("CachedLatestVersion() due = false before any check, want true")
+	}
+
+	if err := RecordUpdateCheck("1.2.3", now); err != nil {
+		t.Fatalf("RecordUpdateCheck() error = %v", err)
+	}
+
+	latest, due := CachedLatestVersion(now.Add(time.Hour))
+	if latest != "1.2.3" || due {
+		t.Errorf("CachedLatestVersion(+1h) = %q, %v, want %q, false", latest, due, "1.2.3")
+	}
+
+	if _, due := CachedLatestVersion(now.Add(25 * time.Hour)); !due {
+		t.Error("CachedLatestVersion(+25h) due = false, want true")
+	}
+
+	if !GetUpdateCheck() {
+		t.Error("GetUpdateCheck() = false by default, want true")
+	}
+}
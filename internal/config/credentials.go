@@ -6,11 +6,22 @@ import (
 	"path/filepath"
 )
 
+// Credential sources reported in Credential.Source.
+const (
+	// CredentialSourceFile means the credential was read from credentials.json.
+	CredentialSourceFile = "file"
+	// CredentialSourceEnv means the credential came from SCRAPS_API_KEY.
+	CredentialSourceEnv = "env"
+	// CredentialSourceKeyring means the API key was read from the OS keychain.
+	CredentialSourceKeyring = "keyring"
+)
+
 // Credential represents stored credentials for a host.
 type Credential struct {
 	APIKey   string `json:"api_key"`
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
+	Source   string `json:"-"` // Where the credential was loaded from
 }
 
 // Credentials is a map of host -> credential.
@@ -76,25 +87,102 @@ func GetCredential(host string) (*Credential, error) {
 	}
 
 	// Check environment variable first
-	if apiKey := os.Getenv("SCRAPS_API_KEY"); apiKey != "" {
+	if apiKey := EnvAPIKey(); apiKey != "" {
 		return &Credential{
 			APIKey: apiKey,
+			Source: CredentialSourceEnv,
 		}, nil
 	}
 
+	return GetStoredCredential(host)
+}
+
+// GetStoredCredential returns the saved credential for a host, ignoring
+// SCRAPS_API_KEY. It returns nil if the host has no saved credential.
+func GetStoredCredential(host string) (*Credential, error) {
 	creds, err := LoadCredentials()
 	if err != nil {
 		return nil, err
 	}
 
+	useKeyring := GetCredentialStore() == CredentialStoreKeyring
+	if useKeyring {
+		creds = migrateToKeyring(creds)
+	}
+
 	cred, ok := creds[host]
 	if !ok {
 		return nil, nil
 	}
 
+	cred.Source = CredentialSourceFile
+	if cred.APIKey != "" {
+		return &cred, nil
+	}
+
+	// The key may have been stored in the keyring before the backend was
+	// switched back to file, so look there whatever the current setting.
+	key, err := keyringGet(host)
+	if err != nil {
+		if useKeyring {
+			return nil, err
+		}
+		// No usable keyring, so there is nothing to fall back to
+		return &cred, nil
+	}
+	if key == "" {
+		return &cred, nil
+	}
+	cred.APIKey = key
+	cred.Source = CredentialSourceKeyring
+	if !useKeyring {
+		migrateToFile(creds, host, key)
+	}
 	return &cred, nil
 }
 
+// migrateToFile moves the API key for host out of the keyring and back
+// into credentials.json after the backend is switched to file. The keyring
+// entry is only removed once the file has been saved.
+func migrateToFile(creds Credentials, host, apiKey string) {
+	cred := creds[host]
+	cred.APIKey = apiKey
+	creds[host] = cred
+	if err := SaveCredentials(creds); err != nil {
+		return
+	}
+	// Best effort: the key is already in the file
+	_ = keyringDelete(host)
+}
+
+// migrateToKeyring moves API keys still held in credentials.json into the
+// keyring. Keys that cannot be stored stay in the file, which remains the
+// fallback when no keyring is available.
+func migrateToKeyring(creds Credentials) Credentials {
+	migrated := false
+	for host, cred := range creds {
+		if cred.APIKey == "" {
+			continue
+		}
+		if err := keyringSet(host, cred.APIKey); err != nil {
+			continue
+		}
+		cred.APIKey = ""
+		creds[host] = cred
+		migrated = true
+	}
+	if migrated {
+		// Best effort: the keys are already in the keyring
+		_ = SaveCredentials(creds)
+	}
+	return creds
+}
+
+// EnvAPIKey returns the API key from the SCRAPS_API_KEY environment variable.
+func EnvAPIKey() string {
+	return os.Getenv("SCRAPS_API_KEY")
+}
+
 // SetCredential saves a credential for a host.
 func SetCredential(host string, cred Credential) error {
 	if host == "" {
@@ -106,6 +194,13 @@ func SetCredential(host string, cred Credential) error {
 		creds = make(Credentials)
 	}
 
+	// Keep only the metadata in the file when the keyring accepts the key
+	if GetCredentialStore() == CredentialStoreKeyring {
+		if err := keyringSet(host, cred.APIKey); err == nil {
+			cred.APIKey = ""
+		}
+	}
+
 	creds[host] = cred
 	return SaveCredentials(creds)
 }
@@ -121,10 +216,52 @@ func RemoveCredential(host string) error {
 		return err
 	}
 
+	// Keys that fell back to the file have nothing in the keyring
+	if creds[host].APIKey == "" {
+		if err := removeKeyringKey(host); err != nil {
+			return err
+		}
+	}
+
 	delete(creds, host)
 	return SaveCredentials(creds)
 }
 
+// RemoveAllCredentials removes the credentials for every host and returns
+// how many hosts were cleared.
+func RemoveAllCredentials() (int, error) {
+	creds, err := LoadCredentials()
+	if err != nil {
+		return 0, err
+	}
+
+	for host, cred := range creds {
+		if cred.APIKey != "" {
+			continue
+		}
+		if err := removeKeyringKey(host); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := SaveCredentials(make(Credentials)); err != nil {
+		return 0, err
+	}
+	return len(creds), nil
+}
+
+// removeKeyringKey deletes the keyring entry for host. Entries are removed
+// whatever the current backend, since keys stored before switching to file
+// stay in the keyring. Without the keyring backend configured, a keyring
+// that cannot be reached is not an error.
+func removeKeyringKey(host string) error {
+	err := keyringDelete(host)
+	if err != nil && GetCredentialStore() != CredentialStoreKeyring {
+		return nil
+	}
+	return err
+}
+
 // HasCredential checks if there is a credential for the host.
 func HasCredential(host string) bool {
 	cred, err := GetCredential(host)
@@ -150,3 +150,65 @@ func TestMultipleHosts(t *testing.T) {
 		}
 	}
 }
+
+func TestGetCredentialFromEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	host := "https://test.example.com"
+	if err := SetCredential(host, Credential{APIKey: "file-key"}); err != nil {
+		t.Fatalf("SetCredential() error = %v", err)
+	}
+
+	got, err := GetCredential(host)
+	if err != nil {
+		t.Fatalf("GetCredential() error = %v", err)
+	}
+	if got.Source != CredentialSourceFile {
+		t.Errorf("Source = %v, want %v", got.Source, CredentialSourceFile)
+	}
+
+	t.Setenv("SCRAPS_API_KEY", "env-key")
+
+	got, err = GetCredential(host)
+	if err != nil {
+		t.Fatalf("GetCredential() error = %v", err)
+	}
+	if got.APIKey != "env-key" {
+		t.Errorf("APIKey = %v, want env-key", got.APIKey)
+	}
+	if got.Source != CredentialSourceEnv {
+		t.Errorf("Source = %v, want %v", got.Source, CredentialSourceEnv)
+	}
+}
+
+func TestRemoveAllCredentials(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	for _, host := range []string{"https://a.example.com", "https://b.example.com"} {
+		if err := SetCredential(host, Credential{APIKey: "key"}); err != nil {
+			t.Fatalf("SetCredential() error = %v", err)
+		}
+	}
+
+	n, err := RemoveAllCredentials()
+	if err != nil {
+		t.Fatalf("RemoveAllCredentials() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("RemoveAllCredentials() = %d, want 2", n)
+	}
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("LoadCredentials() error = %v", err)
+	}
+	if len(creds) != 0 {
+		t.Errorf("Expected no credentials, got %d", len(creds))
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Issue is a problem found while validating the configuration files.
+type Issue struct {
+	File    string `json:"file"`
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidateConfigFile checks config.json strictly: unknown keys, values of the
+// wrong JSON type and values the key itself rejects are all reported. A
+// missing config file is not an issue.
+func ValidateConfigFile() ([]Issue, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []Issue{{File: "config.json", Message: fmt.Sprintf("invalid JSON: %v", err)}}, nil
+	}
+
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var issues []Issue
+	for _, key := range keys {
+		if msg := validateRawKey(key, raw[key]); msg != "" {
+			issues = append(issues, Issue{File: "config.json", Key: key, Message: msg})
+		}
+	}
+	return issues, nil
+}
+
+// validateRawKey decodes a single config.json entry with unknown fields
+// disallowed and runs it through the key's own validation. It returns an
+// empty string when the entry is valid.
+func validateRawKey(key string, raw json.RawMessage) string {
+	doc, err := json.Marshal(map[string]json.RawMessage{key: raw})
+	if err != nil {
+		return err.Error()
+	}
+
+	var cfg Config
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Sprintf("expected a %s value, got %s", typeErr.Type, typeErr.Value)
+		}
+		if strings.Contains(err.Error(), "unknown field") {
+			return fmt.Sprintf("unknown key (valid keys: %s)", strings.Join(Keys(), ", "))
+		}
+		return err.Error()
+	}
+
+	spec, ok := keySpecs[key]
+	if !ok {
+		// Fields the CLI maintains itself, such as the update check cache
+		return ""
+	}
+	value := spec.get(&cfg)
+	if err := spec.set(&Config{}, value); err != nil {
+		return err.Error()
+	}
+	if key == "default_host" {
+		if err := CheckHostURL(value); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// CheckHostURL reports whether host is an absolute http or https URL.
+func CheckHostURL(host string) error {
+	u, err := url.Parse(host)
+	if err != nil {
+		return fmt.Errorf("malformed host URL %q: %v", host, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("host URL %q must start with http:// or https://", host)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("host URL %q has no hostname", host)
+	}
+	return nil
+}
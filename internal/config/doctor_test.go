@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantKeys []string
+	}{
+		{
+			name:    "valid config",
+			content: `{"default_host": "https://api.scraps.sh", "output_format": "json", "request_timeout_seconds": 10}`,
+		},
+		{
+			name:     "unknown key",
+			content:  `{"output_fromat": "json"}`,
+			wantKeys: []string{"output_fromat"},
+		},
+		{
+			name:     "invalid output format",
+			content:  `{"output_format": "xml"}`,
+			wantKeys: []string{"output_format"},
+		},
+		{
+			name:     "wrong type",
+			content:  `{"request_timeout_seconds": "60"}`,
+			wantKeys: []string{"request_timeout_seconds"},
+		},
+		{
+			name:     "malformed host",
+			content:  `{"default_host": "api.scraps.sh", "mask_secrets": true}`,
+			wantKeys: []string{"default_host"},
+		},
+		{
+			name:     "invalid JSON",
+			content:  `{"default_host":`,
+			wantKeys: []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("SCRAPS_CONFIG_DIR", dir)
+			if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			issues, err := ValidateConfigFile()
+			if err != nil {
+				t.Fatalf("ValidateConfigFile() error = %v", err)
+			}
+			if len(issues) != len(tt.wantKeys) {
+				t.Fatalf("ValidateConfigFile() = %+v, want issues for %v", issues, tt.wantKeys)
+			}
+			for i, issue := range issues {
+				if issue.Key != tt.wantKeys[i] {
+					t.Errorf("issue %d key = %q, want %q", i, issue.Key, tt.wantKeys[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfigFileMissing(t *testing.T) {
+	t.Setenv("SCRAPS_CONFIG_DIR", t.TempDir())
+
+	issues, err := ValidateConfigFile()
+	if err != nil || len(issues) != 0 {
+		t.Errorf("ValidateConfigFile() = %v, %v, want no issues", issues, err)
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Credential store backends selectable via the credential_store config key.
+const (
+	// CredentialStoreFile keeps API keys in credentials.json.
+	CredentialStoreFile = "file"
+	// CredentialStoreKeyring keeps API keys in the OS keychain. credentials.json
+	// still records the host, username and user ID, but not the key.
+	CredentialStoreKeyring = "keyring"
+)
+
+// keyringService is the service name API keys are stored under.
+const keyringService = "scraps-cli"
+
+// CredentialStores lists the accepted credential_store values.
+var CredentialStores = []string{CredentialStoreFile, CredentialStoreKeyring}
+
+// GetCredentialStore returns the configured credential backend.
+func GetCredentialStore() string {
+	cfg, err := LoadConfig()
+	if err != nil || cfg.CredentialStore == "" {
+		return CredentialStoreFile
+	}
+	return cfg.CredentialStore
+}
+
+// keyringGet returns the API key stored in the keyring for host, or ""
+// if there is none.
+func keyringGet(host string) (string, error) {
+	key, err := keyring.Get(keyringService, host)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", nil
+	}
+	return key, err
+}
+
+// keyringSet stores the API key for host in the keyring.
+func keyringSet(host, apiKey string) error {
+	return keyring.Set(keyringService, host, apiKey)
+}
+
+// keyringDelete removes the API key for host from the keyring, ignoring
+// missing entries.
+func keyringDelete(host string) error {
+	err := keyring.Delete(keyringService, host)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
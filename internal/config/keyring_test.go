@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestKeyringCredentialStore(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+	keyring.MockInit()
+
+	host := "https://test.example.com"
+
+	// A credential saved before switching backends lives in the file
+	if err := SetCredential(host, Credential{APIKey: "scraps_old", Username: "testuser"}); err != nil {
+		t.Fatalf("SetCredential() error = %v", err)
+	}
+	if err := SetValue("credential_store", CredentialStoreKeyring); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	// First keyring use migrates the key out of the file
+	cred, err := GetCredential(host)
+	if err != nil {
+		t.Fatalf("GetCredential() error = %v", err)
+	}
+	if cred.APIKey != "scraps_old" {
+		t.Errorf("APIKey = %v, want scraps_old", cred.APIKey)
+	}
+	if cred.Source != CredentialSourceKeyring {
+		t.Errorf("Source = %v, want %v", cred.Source, CredentialSourceKeyring)
+	}
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("LoadCredentials() error = %v", err)
+	}
+	if creds[host].APIKey != "" {
+		t.Error("API key still stored in credentials.json after migration")
+	}
+	if creds[host].Username != "testuser" {
+		t.Errorf("Username = %v, want testuser", creds[host].Username)
+	}
+
+	// New credentials go straight to the keyring
+	if err := SetCredential(host, Credential{APIKey: "scraps_new", Username: "testuser"}); err != nil {
+		t.Fatalf("SetCredential() error = %v", err)
+	}
+	if key, _ := keyring.Get(keyringService, host); key != "scraps_new" {
+		t.Errorf("keyring key = %v, want scraps_new", key)
+	}
+
+	if err := RemoveCredential(host); err != nil {
+		t.Fatalf("RemoveCredential() error = %v", err)
+	}
+	if _, err := keyring.Get(keyringService, host); err != keyring.ErrNotFound {
+		t.Errorf("keyring.Get() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestKeyringSwitchBackToFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+	keyring.MockInit()
+
+	host := "https://test.example.com"
+	other := "https://other.example.com"
+
+	if err := SetValue("credential_store", CredentialStoreKeyring); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	for _, h := range []string{host, other} {
+		if err := SetCredential(h, Credential{APIKey: "scraps_" + h, Username: "testuser"}); err != nil {
+			t.Fatalf("SetCredential() error = %v", err)
+		}
+	}
+	if err := SetValue("credential_store", CredentialStoreFile); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+
+	// Keys left in the keyring are still found, and move back to the file
+	cred, err := GetCredential(host)
+	if err != nil {
+		t.Fatalf("GetCredential() error = %v", err)
+	}
+	if cred.APIKey != "scraps_"+host {
+		t.Errorf("APIKey = %q, want scraps_%s", cred.APIKey, host)
+	}
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("LoadCredentials() error = %v", err)
+	}
+	if creds[host].APIKey != "scraps_"+host {
+		t.Error("API key not moved back into credentials.json")
+	}
+	if _, err := keyring.Get(keyringService, host); err != keyring.ErrNotFound {
+		t.Errorf("keyring.Get() error = %v, want ErrNotFound after moving the key", err)
+	}
+
+	// Removing a host not yet read again clears its keyring entry too
+	if err := RemoveCredential(other); err != nil {
+		t.Fatalf("RemoveCredential() error = %v", err)
+	}
+	if _, err := keyring.Get(keyringService, other); err != keyring.ErrNotFound {
+		t.Errorf("keyring.Get() error = %v, want ErrNotFound", err)
+	}
+}
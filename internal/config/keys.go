@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// keySpec describes how a named configuration key is read, validated and reset.
+type keySpec struct {
+	get   func(cfg *Config) string
+	set   func(cfg *Config, value string) error
+	unset func(cfg *Config)
+}
+
+var keySpecs = map[string]keySpec{
+	"agent_id": {
+		get: func(cfg *Config) string { return cfg.AgentID },
+		set: func(cfg *Config, value string) error {
+			if strings.TrimSpace(value) == "" {
+				return fmt.Errorf("agent_id cannot be empty")
+			}
+			cfg.AgentID = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.AgentID = "" },
+	},
+	"cache_ttl_seconds": {
+		get: func(cfg *Config) string {
+			if cfg.CacheTTLSeconds == nil {
+				return strconv.Itoa(DefaultCacheTTLSeconds)
+			}
+			return strconv.Itoa(*cfg.CacheTTLSeconds)
+		},
+		set: func(cfg *Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return fmt.Errorf("cache_ttl_seconds must be a non-negative integer (0 disables the cache)")
+			}
+			cfg.CacheTTLSeconds = &n
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.CacheTTLSeconds = nil },
+	},
+	"credential_store": {
+		get: func(cfg *Config) string {
+			if cfg.CredentialStore == "" {
+				return CredentialStoreFile
+			}
+			return cfg.CredentialStore
+		},
+		set: func(cfg *Config, value string) error {
+			if !slices.Contains(CredentialStores, value) {
+				return fmt.Errorf("credential_store must be one of: %s", strings.Join(CredentialStores, ", "))
+			}
+			cfg.CredentialStore = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.CredentialStore = "" },
+	},
+	"default_host": {
+		get: func(cfg *Config) string { return cfg.DefaultHost },
+		set: func(cfg *Config, value string) error {
+			host, err := NormalizeHost(value)
+			if err != nil {
+				return fmt.Errorf("default_host: %w", err)
+			}
+			cfg.DefaultHost = host
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.DefaultHost = DefaultHost },
+	},
+	"default_store": {
+		get: func(cfg *Config) string { return cfg.DefaultStore },
+		set: func(cfg *Config, value string) error {
+			value = strings.TrimSpace(value)
+			if value == "" || strings.ContainsAny(value, "/:") {
+				return fmt.Errorf("default_store must be a store slug")
+			}
+			cfg.DefaultStore = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.DefaultStore = "" },
+	},
+	"mask_secrets": {
+		get: func(cfg *Config) string { return strconv.FormatBool(cfg.MaskSecrets) },
+		set: func(cfg *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("mask_secrets must be true or false")
+			}
+			cfg.MaskSecrets = b
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.MaskSecrets = false },
+	},
+	"output_format": {
+		get: func(cfg *Config) string { return cfg.OutputFormat },
+		set: func(cfg *Config, value string) error {
+			if !IsValidOutputFormat(value) {
+				return fmt.Errorf("output_format must be one of: %s", strings.Join(OutputFormats, ", "))
+			}
+			cfg.OutputFormat = value
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.OutputFormat = DefaultOutputFormat },
+	},
+	"relative_dates": {
+		get: func(cfg *Config) string { return strconv.FormatBool(cfg.RelativeDates) },
+		set: func(cfg *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("relative_dates must be true or false")
+			}
+			cfg.RelativeDates = b
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.RelativeDates = false },
+	},
+	"request_timeout_seconds": {
+		get: func(cfg *Config) string { return strconv.Itoa(cfg.RequestTimeoutSeconds) },
+		set: func(cfg *Config, value string) error {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return fmt.Errorf("request_timeout_seconds must be a non-negative integer (0 disables the timeout)")
+			}
+			cfg.RequestTimeoutSeconds = n
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.RequestTimeoutSeconds = DefaultRequestTimeoutSeconds },
+	},
+	"require_typed_confirmation": {
+		get: func(cfg *Config) string { return strconv.FormatBool(cfg.RequireTypedConfirm) },
+		set: func(cfg *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("require_typed_confirmation must be true or false")
+			}
+			cfg.RequireTypedConfirm = b
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.RequireTypedConfirm = false },
+	},
+	"update_check": {
+		get: func(cfg *Config) string {
+			return strconv.FormatBool(cfg.UpdateCheck == nil || *cfg.UpdateCheck)
+		},
+		set: func(cfg *Config, value string) error {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("update_check must be true or false")
+			}
+			cfg.UpdateCheck = &b
+			return nil
+		},
+		unset: func(cfg *Config) { cfg.UpdateCheck = nil },
+	},
+}
+
+// Keys returns the names of all configuration keys, sorted.
+func Keys() []string {
+	keys := make([]string, 0, len(keySpecs))
+	for k := range keySpecs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupKey returns the spec for a key or a descriptive error.
+func lookupKey(key string) (keySpec, error) {
+	spec, ok := keySpecs[key]
+	if !ok {
+		return keySpec{}, fmt.Errorf("unknown config key %q (valid keys: %s)", key, strings.Join(Keys(), ", "))
+	}
+	return spec, nil
+}
+
+// GetValue returns the current value of a configuration key.
+func GetValue(key string) (string, error) {
+	spec, err := lookupKey(key)
+	if err != nil {
+		return "", err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return spec.get(cfg), nil
+}
+
+// SetValue validates and saves a configuration key.
+func SetValue(key, value string) error {
+	spec, err := lookupKey(key)
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := spec.set(cfg, value); err != nil {
+		return err
+	}
+	return SaveConfig(cfg)
+}
+
+// UnsetValue resets a configuration key to its default.
+func UnsetValue(key string) error {
+	spec, err := lookupKey(key)
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	spec.unset(cfg)
+	return SaveConfig(cfg)
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSetAndGetValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	tests := []struct {
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{key: "default_host", value: "https://custom.example.com"},
+		{key: "output_format", value: "json"},
+		{key: "output_format", value: "xml", wantErr: true},
+		{key: "request_timeout_seconds", value: "60"},
+		{key: "request_timeout_seconds", value: "-1", wantErr: true},
+		{key: "request_timeout_seconds", value: "abc", wantErr: true},
+		{key: "agent_id", value: "my-agent"},
+		{key: "agent_id", value: " ", wantErr: true},
+		{key: "default_store", value: "mystore"},
+		{key: "default_store", value: "mystore/myrepo", wantErr: true},
+		{key: "default_store", value: "", wantErr: true},
+		{key: "relative_dates", value: "true"},
+		{key: "relative_dates", value: "maybe", wantErr: true},
+		{key: "no_such_key", value: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			err := SetValue(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetValue(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := GetValue(tt.key)
+			if err != nil {
+				t.Fatalf("GetValue(%q) error = %v", tt.key, err)
+			}
+			if got != tt.value {
+				t.Errorf("GetValue(%q) = %q, want %q", tt.key, got, tt.value)
+			}
+		})
+	}
+}
+
+func TestUnsetValue(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	if err := SetValue("output_format", "json"); err != nil {
+		t.Fatalf("SetValue() error = %v", err)
+	}
+	if err := UnsetValue("output_format"); err != nil {
+		t.Fatalf("UnsetValue() error = %v", err)
+	}
+
+	got, err := GetValue("output_format")
+	if err != nil {
+		t.Fatalf("GetValue() error = %v", err)
+	}
+	if got != DefaultOutputFormat {
+		t.Errorf("GetValue(output_format) = %q, want %q", got, DefaultOutputFormat)
+	}
+}
@@ -0,0 +1,59 @@
+// Package log writes an optional diagnostic log. Nothing is recorded until
+// a destination is set with Open or SetOutput.
+package log
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// logger discards everything until a destination is set.
+var logger = slog.New(slog.DiscardHandler)
+
+// Open appends the log to the file at path, creating it if needed, and
+// records messages at level and above. The caller closes the file.
+func Open(path string, level slog.Level) (io.Closer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	SetOutput(f, level)
+	return f, nil
+}
+
+// SetOutput sends the log to w. A nil w turns logging off again.
+func SetOutput(w io.Writer, level slog.Level) {
+	if w == nil {
+		logger = slog.New(slog.DiscardHandler)
+		return
+	}
+	logger = slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// ParseLevel parses a level name: debug, info, warn or error. An empty
+// name is debug, since a log is only written when asked for.
+func ParseLevel(name string) (slog.Level, error) {
+	if name == "" {
+		return slog.LevelDebug, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(name))); err != nil {
+		return 0, fmt.Errorf("invalid log level %q (use debug, info, warn or error)", name)
+	}
+	return level, nil
+}
+
+// Debug logs at debug level.
+func Debug(msg string, args ...any) { logger.Debug(msg, args...) }
+
+// Info logs at info level.
+func Info(msg string, args ...any) { logger.Info(msg, args...) }
+
+// Warn logs at warn level.
+func Warn(msg string, args ...any) { logger.Warn(msg, args...) }
+
+// Error logs at error level.
+func Error(msg string, args ...any) { logger.Error(msg, args...) }
@@ -0,0 +1,61 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{name: "", want: slog.LevelDebug},
+		{name: "debug", want: slog.LevelDebug},
+		{name: "INFO", want: slog.LevelInfo},
+		{name: "warn", want: slog.LevelWarn},
+		{name: "error", want: slog.LevelError},
+		{name: "loud", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	defer SetOutput(nil, 0)
+
+	var buf bytes.Buffer
+	SetOutput(&buf, slog.LevelInfo)
+	Debug("hidden")
+	Info("stream connected", "url", "https://example.com/stream")
+	Warn("stream dropped")
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") {
+		t.Errorf("debug message logged at info level:\n%s", out)
+	}
+	for _, want := range []string{"level=INFO", `msg="stream connected"`, "url=https://example.com/stream", "level=WARN"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+
+	SetOutput(nil, 0)
+	buf.Reset()
+	Error("after reset")
+	if buf.Len() != 0 {
+		t.Errorf("logged after SetOutput(nil): %s", buf.String())
+	}
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Event is a decoded repository stream event.
+type Event interface {
+	EventType() string
+}
+
+// EventType returns the event type.
+func (e CommitEvent) EventType() string { return e.Type }
+
+// EventType returns the event type.
+func (e BranchEvent) EventType() string { return e.Type }
+
+// EventType returns the event type.
+func (e ActivityEvent) EventType() string { return e.Type }
+
+// EventType returns the event type.
+func (e AgentClaimEvent) EventType() string { return e.Type }
+
+// UnknownEvent is an event without a dedicated type. Fields holds the
+// decoded JSON object and Raw the original bytes.
+type UnknownEvent struct {
+	Type   string
+	Fields map[string]any
+	Raw    json.RawMessage
+}
+
+// EventType returns the event type.
+func (e UnknownEvent) EventType() string { return e.Type }
+
+// DecodeEvent decodes a stream message into a typed event. Messages whose
+// type has no dedicated struct are returned as UnknownEvent.
+func DecodeEvent(data []byte) (Event, error) {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case head.Type == "commit":
+		var e CommitEvent
+		err := json.Unmarshal(data, &e)
+		return e, err
+	case strings.HasPrefix(head.Type, "branch"):
+		var e BranchEvent
+		err := json.Unmarshal(data, &e)
+		return e, err
+	case head.Type == "activity":
+		var e ActivityEvent
+		err := json.Unmarshal(data, &e)
+		return e, err
+	case head.Type == "agent_claim", head.Type == "agent_release":
+		var e AgentClaimEvent
+		err := json.Unmarshal(data, &e)
+		return e, err
+	}
+
+	e := UnknownEvent{Type: head.Type, Raw: append(json.RawMessage(nil), data...)}
+	if err := json.Unmarshal(data, &e.Fields); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantType string
+		check    func(t *testing.T, e Event)
+	}{
+		{
+			name:     "commit",
+			data:     `{"type":"commit","sha":"abc123","message":"Fix bug","files":[{"action":"add","path":"a.go"}]}`,
+			wantType: "commit",
+			check: func(t *testing.T, e Event) {
+				c, ok := e.(CommitEvent)
+				if !ok {
+					t.Fatalf("got %T, want CommitEvent", e)
+				}
+				if c.SHA != "abc123" || len(c.Files) != 1 {
+					t.Errorf("CommitEvent = %+v", c)
+				}
+			},
+		},
+		{
+			name:     "branch",
+			data:     `{"type":"branch_created","branch":"feature"}`,
+			wantType: "branch_created",
+			check: func(t *testing.T, e Event) {
+				b, ok := e.(BranchEvent)
+				if !ok {
+					t.Fatalf("got %T, want BranchEvent", e)
+				}
+				if b.Branch != "feature" {
+					t.Errorf("Branch = %v, want feature", b.Branch)
+				}
+			},
+		},
+		{
+			name:     "activity",
+			data:     `{"type":"activity","activity":{"type":"claim","agent_id":"a1","patterns":["src/**"]}}`,
+			wantType: "activity",
+			check: func(t *testing.T, e Event) {
+				a, ok := e.(ActivityEvent)
+				if !ok {
+					t.Fatalf("got %T, want ActivityEvent", e)
+				}
+				if a.Activity.AgentID != "a1" {
+					t.Errorf("AgentID = %v, want a1", a.Activity.AgentID)
+				}
+			},
+		},
+		{
+			name:     "agent claim",
+			data:     `{"type":"agent_release","agent_id":"a1","patterns":["src/**"]}`,
+			wantType: "agent_release",
+			check: func(t *testing.T, e Event) {
+				c, ok := e.(AgentClaimEvent)
+				if !ok {
+					t.Fatalf("got %T, want AgentClaimEvent", e)
+				}
+				if len(c.Patterns) != 1 || c.Patterns[0] != "src/**" {
+					t.Errorf("Patterns = %v, want [src/**]", c.Patterns)
+				}
+			},
+		},
+		{
+			name:     "unknown",
+			data:     `{"type":"file_write","agent_id":"a1","path":"a.go"}`,
+			wantType: "file_write",
+			check: func(t *testing.T, e Event) {
+				u, ok := e.(UnknownEvent)
+				if !ok {
+					t.Fatalf("got %T, want UnknownEvent", e)
+				}
+				if u.Fields["path"] != "a.go" {
+					t.Errorf("Fields[path] = %v, want a.go", u.Fields["path"])
+				}
+				if len(u.Raw) == 0 {
+					t.Error("Raw is empty")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := DecodeEvent([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("DecodeEvent() error = %v", err)
+			}
+			if e.EventType() != tt.wantType {
+				t.Errorf("EventType() = %v, want %v", e.EventType(), tt.wantType)
+			}
+			tt.check(t, e)
+		})
+	}
+}
+
+func TestDecodeEventInvalid(t *testing.T) {
+	if _, err := DecodeEvent([]byte("not json")); err == nil {
+		t.Error("DecodeEvent() error = nil, want error")
+	}
+}
@@ -1,7 +1,10 @@
 // Package model defines the data types used throughout the scraps CLI.
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // User represents an authenticated user.
 type User struct {
@@ -34,6 +37,7 @@ type Repository struct {
 	Name          string `json:"name"`
 	DefaultBranch string `json:"default_branch,omitempty"`
 	CreatedAt     string `json:"created_at"`
+	Archived      bool   `json:"archived,omitempty"`
 	Store         string `json:"store,omitempty"` // Added by client for convenience
 }
 
@@ -52,6 +56,12 @@ type FileTreeEntry struct {
 	SHA  string `json:"sha,omitempty"`
 }
 
+// Branch represents a repository branch.
+type Branch struct {
+	Name string `json:"name"`
+	SHA  string `json:"sha,omitempty"`
+}
+
 // Commit represents a git commit.
 type Commit struct {
 	SHA       string       `json:"sha,omitempty"`
@@ -62,6 +72,17 @@ type Commit struct {
 	Timestamp int64        `json:"timestamp,omitempty"`
 }
 
+// CommitDetail represents a single commit with its changed files.
+type CommitDetail struct {
+	SHA       string       `json:"sha,omitempty"`
+	Message   string       `json:"message,omitempty"`
+	Author    CommitAuthor `json:"author,omitempty"`
+	Date      string       `json:"date,omitempty"`
+	Timestamp int64        `json:"timestamp,omitempty"`
+	Parents   []string     `json:"parents,omitempty"`
+	Files     []FileChange `json:"files,omitempty"`
+}
+
 // CommitAuthor can be a string or an object with name/email.
 type CommitAuthor struct {
 	Name  string `json:"name,omitempty"`
@@ -69,6 +90,32 @@ type CommitAuthor struct {
 	Raw   string `json:"-"` // Used when author is a plain string
 }
 
+// UnmarshalJSON accepts either a plain string ("Jane <jane@x>"), stored in
+// Raw, or an object with name and email fields.
+func (a *CommitAuthor) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*a = CommitAuthor{}
+		return json.Unmarshal(data, &a.Raw)
+	}
+	type plain CommitAuthor
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*a = CommitAuthor(p)
+	return nil
+}
+
+// MarshalJSON writes a string author back as a string so output matches the
+// shape the server sent.
+func (a CommitAuthor) MarshalJSON() ([]byte, error) {
+	if a.Raw != "" && a.Name == "" && a.Email == "" {
+		return json.Marshal(a.Raw)
+	}
+	type plain CommitAuthor
+	return json.Marshal(plain(a))
+}
+
 // APIKey represents an API key.
 type APIKey struct {
 	ID         string  `json:"id"`
@@ -76,15 +123,17 @@ type APIKey struct {
 	KeyPrefix  string  `json:"key_prefix"`
 	CreatedAt  string  `json:"created_at"`
 	LastUsedAt *string `json:"last_used_at,omitempty"`
+	ExpiresAt  *string `json:"expires_at,omitempty"`
 }
 
 // ScopedToken represents a scoped access token.
 type ScopedToken struct {
-	ID        string           `json:"id"`
-	Label     string           `json:"label,omitempty"`
-	Scope     ScopedTokenScope `json:"scope"`
-	CreatedAt string           `json:"created_at"`
-	ExpiresAt *string          `json:"expires_at,omitempty"`
+	ID         string           `json:"id"`
+	Label      string           `json:"label,omitempty"`
+	Scope      ScopedTokenScope `json:"scope"`
+	CreatedAt  string           `json:"created_at"`
+	LastUsedAt *string          `json:"last_used_at,omitempty"`
+	ExpiresAt  *string          `json:"expires_at,omitempty"`
 }
 
 // ScopedTokenScope defines the scope of a scoped token.
@@ -134,10 +183,30 @@ type ClaimResponse struct {
 
 // GetExpiresAtString returns the expires_at value as a string.
 func (c *ClaimResponse) GetExpiresAtString() *string {
-	if c.ExpiresAt == nil {
+	return expiresAtString(c.ExpiresAt)
+}
+
+// Claim represents an active claim on a branch.
+type Claim struct {
+	AgentID   string   `json:"agent_id"`
+	AgentName string   `json:"agent_name,omitempty"`
+	Patterns  []string `json:"patterns"`
+	Claim     string   `json:"claim,omitempty"`
+	ExpiresAt any      `json:"expires_at,omitempty"` // Can be string or number
+}
+
+// GetExpiresAtString returns the expires_at value as a string.
+func (c *Claim) GetExpiresAtString() *string {
+	return expiresAtString(c.ExpiresAt)
+}
+
+// expiresAtString normalizes an expires_at value, which the API sends as
+// either an RFC3339 string or a unix timestamp, to a string.
+func expiresAtString(expiresAt any) *string {
+	if expiresAt == nil {
 		return nil
 	}
-	switch v := c.ExpiresAt.(type) {
+	switch v := expiresAt.(type) {
 	case string:
 		return &v
 	case float64:
@@ -180,6 +249,7 @@ type CommitEvent struct {
 type FileChange struct {
 	Action string `json:"action"` // "add", "delete", "modify"
 	Path   string `json:"path"`
+	Patch  string `json:"patch,omitempty"`
 }
 
 // BranchEvent represents a branch-related event.
@@ -226,6 +296,16 @@ type Reference struct {
 	Repo   string
 	Branch string
 	Path   string
+	Commit string // pinned commit SHA, from a "#sha" suffix
+}
+
+// Ref returns the git ref to read at: the pinned commit if there is one,
+// otherwise the branch.
+func (r *Reference) Ref() string {
+	if r.Commit != "" {
+		return r.Commit
+	}
+	return r.Branch
 }
 
 // ParsedTime parses a time string from the API.
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCommitAuthorUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want CommitAuthor
+	}{
+		{
+			name: "string author",
+			data: `{"author": "Jane <jane@example.com>"}`,
+			want: CommitAuthor{Raw: "Jane <jane@example.com>"},
+		},
+		{
+			name: "object author",
+			data: `{"author": {"name": "Jane", "email": "jane@example.com"}}`,
+			want: CommitAuthor{Name: "Jane", Email: "jane@example.com"},
+		},
+		{
+			name: "null author",
+			data: `{"author": null}`,
+			want: CommitAuthor{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Commit
+			if err := json.Unmarshal([]byte(tt.data), &c); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if c.Author != tt.want {
+				t.Errorf("Author = %+v, want %+v", c.Author, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitAuthorMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		author CommitAuthor
+		want   string
+	}{
+		{name: "string author", author: CommitAuthor{Raw: "Jane"}, want: `"Jane"`},
+		{name: "object author", author: CommitAuthor{Name: "Jane", Email: "j@x"}, want: `{"name":"Jane","email":"j@x"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.author)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,18 +7,34 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/morrisclay/scraps-cli/internal/log"
+)
+
+// Reconnect backoff bounds.
+const (
+	reconnectBaseDelay = 500 * time.Millisecond
+	reconnectMaxDelay  = 30 * time.Second
 )
 
 // Client is an HTTP streaming client.
 type Client struct {
-	url        string
-	apiKey     string
-	OnMessage  func([]byte)
-	OnError    func(error)
-	OnClose    func()
-	httpClient *http.Client
-	cancel     context.CancelFunc
-	done       chan struct{}
+	url       string
+	apiKey    string
+	OnMessage func([]byte)
+	OnError   func(error)
+	OnClose   func()
+	// OnReconnect is called before each reconnection attempt with the
+	// attempt number (starting at 1) and the delay before it is made.
+	OnReconnect   func(attempt int, delay time.Duration)
+	httpClient    *http.Client
+	ctx           context.Context
+	cancel        context.CancelFunc
+	done          chan struct{}
+	maxReconnects int
+	baseDelay     time.Duration
+	lastEventID   string
 }
 
 // NewClient creates a new streaming client.
@@ -28,59 +44,153 @@ func NewClient(url, apiKey string) *Client {
 		apiKey:     apiKey,
 		httpClient: &http.Client{},
 		done:       make(chan struct{}),
+		baseDelay:  reconnectBaseDelay,
 	}
 }
 
+// WithReconnect enables automatic reconnection after the stream drops.
+// Up to max consecutive attempts are made with exponential backoff; the
+// count resets once a connection succeeds. Reconnection is off by default.
+func (c *Client) WithReconnect(max int) *Client {
+	c.maxReconnects = max
+	return c
+}
+
+// WithLastEventID sets the event ID sent in the Last-Event-ID header so the
+// server resumes the stream after that event.
+func (c *Client) WithLastEventID(id string) *Client {
+	c.lastEventID = id
+	return c
+}
+
+// LastEventID returns the ID of the last SSE event received, if any.
+func (c *Client) LastEventID() string {
+	return c.lastEventID
+}
+
 // Connect starts the streaming connection.
 func (c *Client) Connect() error {
-	ctx, cancel := context.WithCancel(context.Background())
-	c.cancel = cancel
+	c.ctx, c.cancel = context.WithCancel(context.Background())
 
-	req, err := http.NewRequestWithContext(ctx, "GET", c.url, nil)
+	resp, err := c.dial()
 	if err != nil {
 		return err
 	}
 
+	go c.readLoop(resp)
+	return nil
+}
+
+// dial opens the HTTP stream, resuming from the last event ID if known.
+func (c *Client) dial() (*http.Response, error) {
+	req, err := http.NewRequestWithContext(c.ctx, "GET", c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Connection", "keep-alive")
+	if c.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", c.lastEventID)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		log.Warn("stream connect failed", "url", c.url, "error", err)
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
-		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		log.Warn("stream connect failed", "url", c.url, "status", resp.StatusCode)
+		return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
-
-	go c.readLoop(resp)
-	return nil
+	log.Info("stream connected", "url", c.url, "last_event_id", c.lastEventID)
+	return resp, nil
 }
 
-// readLoop reads events from the stream.
+// readLoop reads events from the stream, reconnecting if enabled.
 func (c *Client) readLoop(resp *http.Response) {
 	defer func() {
-		resp.Body.Close()
 		if c.OnClose != nil {
 			c.OnClose()
 		}
 		close(c.done)
 	}()
 
-	reader := bufio.NewReader(resp.Body)
-	var dataBuffer strings.Builder
-
 	for {
-		line, err := reader.ReadString('\n')
+		err := c.readEvents(resp)
+		resp.Body.Close()
+
+		// Closed by the caller; nothing to report
+		if c.ctx.Err() != nil {
+			log.Info("stream closed", "url", c.url)
+			return
+		}
+		log.Warn("stream dropped", "url", c.url, "error", err)
+
+		if c.maxReconnects <= 0 {
+			if c.OnError != nil {
+				c.OnError(err)
+			}
+			return
+		}
+
+		resp, err = c.reconnect()
 		if err != nil {
 			if c.OnError != nil {
 				c.OnError(err)
 			}
 			return
 		}
+	}
+}
+
+// reconnect retries the connection with exponential backoff.
+func (c *Client) reconnect() (*http.Response, error) {
+	var lastErr error
+	delay := c.baseDelay
+
+	for attempt := 1; attempt <= c.maxReconnects; attempt++ {
+		if c.OnReconnect != nil {
+			c.OnReconnect(attempt, delay)
+		}
+		log.Info("stream reconnecting", "url", c.url, "attempt", attempt, "delay", delay)
+
+		select {
+		case <-time.After(delay):
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		}
+
+		resp, err := c.dial()
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		delay *= 2
+		if delay > reconnectMaxDelay {
+			delay = reconnectMaxDelay
+		}
+	}
+
+	log.Error("stream reconnect gave up", "url", c.url, "attempts", c.maxReconnects, "error", lastErr)
+	return nil, fmt.Errorf("reconnect failed after %d attempts: %w", c.maxReconnects, lastErr)
+}
+
+// readEvents reads events until the stream ends, returning the read error.
+func (c *Client) readEvents(resp *http.Response) error {
+	reader := bufio.NewReader(resp.Body)
+	var dataBuffer strings.Builder
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
 
 		line = strings.TrimSpace(line)
 
@@ -95,11 +205,13 @@ func (c *Client) readLoop(resp *http.Response) {
 			continue
 		}
 
-		// SSE format: "data: {...}"
+		// SSE format: "data: {...}", "id: ..."
 		if strings.HasPrefix(line, "data:") {
 			data := strings.TrimPrefix(line, "data:")
 			data = strings.TrimSpace(data)
 			dataBuffer.WriteString(data)
+		} else if strings.HasPrefix(line, "id:") {
+			c.lastEventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
 		} else if strings.HasPrefix(line, "{") {
 			// Plain JSON (newline-delimited)
 			if c.OnMessage != nil {
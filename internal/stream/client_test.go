@@ -0,0 +1,87 @@
+package stream
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientReconnect(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	var resumedFrom string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		if n == 2 {
+			resumedFrom = r.Header.Get("Last-Event-ID")
+		}
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "id: %d\ndata: {\"n\":%d}\n\n", n, n)
+		w.(http.Flusher).Flush()
+
+		// Drop the first connection; hold the second open
+		if n > 1 {
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	messages := make(chan string, 4)
+	var reconnects int
+
+	client := NewClient(server.URL, "test-key").WithReconnect(3)
+	client.baseDelay = time.Millisecond
+	client.OnMessage = func(data []byte) { messages <- string(data) }
+	client.OnReconnect = func(attempt int, delay time.Duration) { reconnects++ }
+
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	for _, want := range []string{`{"n":1}`, `{"n":2}`} {
+		select {
+		case got := <-messages:
+			if got != want {
+				t.Errorf("message = %s, want %s", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+
+	if reconnects != 1 {
+		t.Errorf("reconnects = %d, want 1", reconnects)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if resumedFrom != "1" {
+		t.Errorf("Last-Event-ID = %q, want 1", resumedFrom)
+	}
+}
+
+func TestClientNoReconnectByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "data: {}\n\n")
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	select {
+	case <-client.Done():
+	case <-time.After(2 * time.Second):
+		t.Fatal("client did not stop after the stream closed")
+	}
+}
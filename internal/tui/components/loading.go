@@ -1,6 +1,8 @@
 package components
 
 import (
+	"errors"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/morrisclay/scraps-cli/internal/tui"
@@ -104,9 +106,14 @@ func RunWithLoading[T any](message string, fn func() (T, error)) (T, error) {
 		p.Send(LoadingDoneMsg{Result: result, Err: err})
 	}()
 
-	if _, runErr := p.Run(); runErr != nil {
+	final, runErr := p.Run()
+	if runErr != nil {
 		return result, runErr
 	}
+	// Quitting with q/ctrl+c leaves the result unset
+	if lm, ok := final.(LoadingModel); ok && !lm.done {
+		return result, errors.New("cancelled")
+	}
 
 	return result, err
 }
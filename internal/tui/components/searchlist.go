@@ -282,6 +282,12 @@ func (m SearchListModel) Selected() *SearchListItem {
 	return m.selected
 }
 
+// Filtering returns whether the filter input is active, in which case esc
+// clears the filter rather than leaving the list.
+func (m SearchListModel) Filtering() bool {
+	return m.filterMode
+}
+
 // Done returns whether the list selection is complete.
 func (m SearchListModel) Done() bool {
 	return m.done
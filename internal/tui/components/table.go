@@ -1,8 +1,16 @@
 package components
 
 import (
+	"cmp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/morrisclay/scraps-cli/internal/tui"
@@ -10,15 +18,26 @@ import (
 
 // TableModel is an interactive table component.
 type TableModel struct {
-	table      table.Model
-	title      string
-	done       bool
-	cancelled  bool
-	selected   table.Row
-	showHelp   bool
-	width      int
-	height     int
-	onSelect   func(row table.Row) tea.Cmd
+	table     table.Model
+	title     string
+	done      bool
+	cancelled bool
+	selected  table.Row
+	showHelp  bool
+	width     int
+	height    int
+	onSelect  func(row table.Row) tea.Cmd
+
+	// Sorting: rows keeps the unsorted order, titles the plain column
+	// titles. sortCol is -1 when the rows are unsorted.
+	rows     []table.Row
+	titles   []string
+	sortCol  int
+	sortDesc bool
+
+	// Filtering: only rows with a cell containing the filter are shown
+	filterMode bool
+	filter     textinput.Model
 }
 
 // TableSelectedMsg is sent when a row is selected.
@@ -35,11 +54,14 @@ type TableColumn struct {
 // NewTable creates a new interactive table.
 func NewTable(title string, columns []TableColumn, rows []table.Row) TableModel {
 	cols := make([]table.Column, len(columns))
+	titles := make([]string, len(columns))
 	for i, c := range columns {
 		cols[i] = table.Column{
 			Title: c.Title,
-			Width: c.Width,
+			// Leave room for the sort indicator
+			Width: max(c.Width, lipgloss.Width(c.Title)+2),
 		}
+		titles[i] = c.Title
 	}
 
 	t := table.New(
@@ -65,9 +87,21 @@ func NewTable(title string, columns []TableColumn, rows []table.Row) TableModel
 		Foreground(lipgloss.Color("#FFFFFF"))
 	t.SetStyles(s)
 
+	// Create filter input
+	ti := textinput.New()
+	ti.Placeholder = "Type to filter..."
+	ti.CharLimit = 100
+	ti.Width = 30
+	ti.PromptStyle = tui.PromptStyle
+	ti.TextStyle = lipgloss.NewStyle()
+
 	return TableModel{
-		table: t,
-		title: title,
+		table:   t,
+		title:   title,
+		rows:    rows,
+		titles:  titles,
+		sortCol: -1,
+		filter:  ti,
 	}
 }
 
@@ -83,6 +117,122 @@ func (m TableModel) WithWidth(width int) TableModel {
 	return m
 }
 
+// WithRows replaces the table rows, keeping the cursor in range and the
+// current sort order and filter.
+func (m TableModel) WithRows(rows []table.Row) TableModel {
+	m.rows = rows
+	m.refreshRows()
+	return m
+}
+
+// refreshRows shows the rows that match the filter in the current sort
+// order, keeping the cursor in range.
+func (m *TableModel) refreshRows() {
+	rows := m.visibleRows()
+	m.table.SetRows(rows)
+	if m.table.Cursor() >= len(rows) {
+		m.table.SetCursor(max(len(rows)-1, 0))
+	}
+}
+
+// SortBy sorts the rows by column col, or restores their original order if
+// col is -1. The cursor stays on the selected row.
+func (m TableModel) SortBy(col int, desc bool) TableModel {
+	if col < -1 || col >= len(m.titles) {
+		return m
+	}
+	m.sortCol, m.sortDesc = col, desc
+
+	selected := m.table.SelectedRow()
+	rows := m.visibleRows()
+	m.table.SetRows(rows)
+	for i, row := range rows {
+		if slices.Equal(row, selected) {
+			m.table.SetCursor(i)
+			break
+		}
+	}
+
+	cols := m.table.Columns()
+	for i := range cols {
+		cols[i].Title = m.titles[i]
+		if i == m.sortCol {
+			if m.sortDesc {
+				cols[i].Title += " ▼"
+			} else {
+				cols[i].Title += " ▲"
+			}
+		}
+	}
+	m.table.SetColumns(cols)
+	return m
+}
+
+// visibleRows returns the rows that match the filter in the current sort
+// order. The sort is stable, so equal cells keep their original order.
+func (m TableModel) visibleRows() []table.Row {
+	rows := filterRows(m.rows, m.filter.Value())
+	if m.sortCol < 0 {
+		return rows
+	}
+	rows = slices.Clone(rows)
+	col := m.sortCol
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := cell(rows[i], col), cell(rows[j], col)
+		if m.sortDesc {
+			return compareCells(b, a) < 0
+		}
+		return compareCells(a, b) < 0
+	})
+	return rows
+}
+
+// filterRows returns the rows with a cell containing query, ignoring case.
+func filterRows(rows []table.Row, query string) []table.Row {
+	if query == "" {
+		return rows
+	}
+
+	query = strings.ToLower(query)
+	var filtered []table.Row
+	for _, row := range rows {
+		if slices.ContainsFunc(row, func(c string) bool {
+			return strings.Contains(strings.ToLower(c), query)
+		}) {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
+
+func cell(row table.Row, col int) string {
+	if col < len(row) {
+		return row[col]
+	}
+	return ""
+}
+
+// sortDateLayouts are the date formats table cells are shown in.
+var sortDateLayouts = []string{"Jan 02, 2006 15:04", "Jan 02, 2006", time.RFC3339}
+
+// compareCells orders two cells as numbers or dates when both parse as one,
+// and otherwise as case-insensitive text.
+func compareCells(a, b string) int {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			return cmp.Compare(x, y)
+		}
+	}
+	for _, layout := range sortDateLayouts {
+		if x, err := time.Parse(layout, a); err == nil {
+			if y, err := time.Parse(layout, b); err == nil {
+				return x.Compare(y)
+			}
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
 // WithOnSelect sets a callback for when a row is selected.
 func (m TableModel) WithOnSelect(fn func(row table.Row) tea.Cmd) TableModel {
 	m.onSelect = fn
@@ -107,12 +257,55 @@ func (m TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.table.SetHeight(msg.Height - 8)
 
 	case tea.KeyMsg:
+		if m.filterMode {
+			switch msg.String() {
+			case "esc":
+				m.filterMode = false
+				m.filter.Blur()
+				m.filter.SetValue("")
+				m.refreshRows()
+			case "enter":
+				m.filterMode = false
+				m.filter.Blur()
+			case "up", "down":
+				// Move through the matches while typing
+				var cmd tea.Cmd
+				m.table, cmd = m.table.Update(msg)
+				cmds = append(cmds, cmd)
+			default:
+				var cmd tea.Cmd
+				m.filter, cmd = m.filter.Update(msg)
+				cmds = append(cmds, cmd)
+				m.refreshRows()
+			}
+			return m, tea.Batch(cmds...)
+		}
+
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("?"))):
 			m.showHelp = !m.showHelp
 			return m, nil
 
+		case key.Matches(msg, key.NewBinding(key.WithKeys("/"))):
+			m.filterMode = true
+			return m, m.filter.Focus()
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+			// Cycle through the columns, then back to the original order
+			next := m.sortCol + 1
+			if next >= len(m.titles) {
+				next = -1
+			}
+			return m.SortBy(next, false), nil
+
+		case key.Matches(msg, key.NewBinding(key.WithKeys("S"))):
+			return m.SortBy(max(m.sortCol, 0), !m.sortDesc), nil
+
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			if len(m.table.Rows()) == 0 {
+				// Nothing matches the filter
+				return m, nil
+			}
 			m.selected = m.table.SelectedRow()
 			m.done = true
 			if m.onSelect != nil {
@@ -123,6 +316,12 @@ func (m TableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+			if m.filter.Value() != "" {
+				// Clear the filter before leaving the table
+				m.filter.SetValue("")
+				m.refreshRows()
+				return m, nil
+			}
 			m.cancelled = true
 			m.done = true
 			return m, tea.Quit
@@ -154,19 +353,40 @@ func (m TableModel) View() string {
 		s += tui.TitleStyle.Render(m.title) + "\n\n"
 	}
 
+	// Filter
+	if f := m.FilterView(); f != "" {
+		s += f + "\n\n"
+	}
+
 	// Table
 	s += m.table.View() + "\n\n"
 
 	// Help
 	if m.showHelp {
-		s += tui.HelpStyle.Render("↑/k up  ↓/j down  enter select  esc quit  ? toggle help")
+		s += tui.HelpStyle.Render("↑/k up  ↓/j down  / filter  s sort by next column  S reverse sort  enter select  esc quit  ? toggle help")
 	} else {
-		s += tui.HelpStyle.Render("↑↓ navigate  enter select  ? help")
+		s += tui.HelpStyle.Render("↑↓ navigate  / filter  s sort  enter select  ? help")
 	}
 
 	return s
 }
 
+// FilterView renders the filter input while it is open or a filter is
+// applied, and is empty otherwise. It is part of View, for callers that
+// render the table themselves.
+func (m TableModel) FilterView() string {
+	if !m.filterMode && m.filter.Value() == "" {
+		return ""
+	}
+	return "Filter: " + m.filter.View()
+}
+
+// Filtering returns whether the filter input is active, in which case keys
+// go to the filter rather than the table.
+func (m TableModel) Filtering() bool {
+	return m.filterMode
+}
+
 // Selected returns the selected row, if any.
 func (m TableModel) Selected() table.Row {
 	return m.selected
@@ -0,0 +1,154 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTableSort(t *testing.T) {
+	columns := []TableColumn{{Title: "NAME", Width: 10}, {Title: "SIZE", Width: 6}, {Title: "CREATED", Width: 14}}
+	rows := []table.Row{
+		{"beta", "10", "Mar 02, 2024"},
+		{"Alpha", "9", "Jan 15, 2025"},
+		{"gamma", "100", "Mar 02, 2024"},
+	}
+	press := func(m tea.Model, key string) tea.Model {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		return m
+	}
+	names := func(m tea.Model) []string {
+		var got []string
+		for _, row := range m.(TableModel).Table().Rows() {
+			got = append(got, row[0])
+		}
+		return got
+	}
+	header := func(m tea.Model, col int) string {
+		return m.(TableModel).Table().Columns()[col].Title
+	}
+
+	var m tea.Model = NewTable("", columns, rows)
+
+	m = press(m, "s")
+	if got, want := names(m), []string{"Alpha", "beta", "gamma"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted by name = %v, want %v", got, want)
+	}
+	if got := header(m, 0); got != "NAME ▲" {
+		t.Errorf("header = %q, want sort indicator", got)
+	}
+
+	m = press(m, "S")
+	if got, want := names(m), []string{"gamma", "beta", "Alpha"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reversed = %v, want %v", got, want)
+	}
+	if got := header(m, 0); got != "NAME ▼" {
+		t.Errorf("header = %q, want descending indicator", got)
+	}
+
+	// Numbers compare numerically
+	m = press(m, "s")
+	if got, want := names(m), []string{"Alpha", "beta", "gamma"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted by size = %v, want %v", got, want)
+	}
+	if got := header(m, 0); got != "NAME" {
+		t.Errorf("previous header = %q, want indicator removed", got)
+	}
+
+	// Dates compare chronologically and ties keep their order
+	m = press(m, "s")
+	if got, want := names(m), []string{"beta", "gamma", "Alpha"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted by date = %v, want %v", got, want)
+	}
+
+	// Cycling past the last column restores the original order
+	m = press(m, "s")
+	if got, want := names(m), []string{"beta", "Alpha", "gamma"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unsorted = %v, want %v", got, want)
+	}
+	if got := header(m, 2); got != "CREATED" {
+		t.Errorf("header = %q, want no indicator", got)
+	}
+}
+
+func TestTableSortKeepsSelection(t *testing.T) {
+	columns := []TableColumn{{Title: "NAME", Width: 10}}
+	rows := []table.Row{{"c"}, {"a"}, {"b"}}
+
+	m := NewTable("", columns, rows)
+	tm, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = tm.(TableModel).SortBy(0, false)
+	if got := m.Table().SelectedRow()[0]; got != "a" {
+		t.Errorf("selected row after sort = %q, want %q", got, "a")
+	}
+
+	// New rows keep the sort order
+	m = m.WithRows([]table.Row{{"z"}, {"y"}})
+	if got := m.Table().Rows()[0][0]; got != "y" {
+		t.Errorf("first row after WithRows = %q, want %q", got, "y")
+	}
+}
+
+func TestTableFilter(t *testing.T) {
+	columns := []TableColumn{{Title: "NAME", Width: 10}, {Title: "ROLE", Width: 8}}
+	rows := []table.Row{{"alice", "owner"}, {"bob", "member"}, {"carol", "Owner"}}
+	typeText := func(m tea.Model, s string) tea.Model {
+		for _, r := range s {
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		return m
+	}
+	names := func(m tea.Model) []string {
+		var got []string
+		for _, row := range m.(TableModel).Table().Rows() {
+			got = append(got, row[0])
+		}
+		return got
+	}
+	esc := tea.KeyMsg{Type: tea.KeyEsc}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	var m tea.Model = NewTable("", columns, rows)
+	m = typeText(m, "/own")
+	if !m.(TableModel).Filtering() {
+		t.Fatal("Filtering() = false after /, want true")
+	}
+	if got, want := names(m), []string{"alice", "carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filtered rows = %v, want %v", got, want)
+	}
+
+	// Sorting applies to the matches
+	m, _ = m.Update(enter)
+	m = typeText(m, "S")
+	if got, want := names(m), []string{"carol", "alice"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filtered and sorted rows = %v, want %v", got, want)
+	}
+
+	// Selection picks from the matches; the cursor followed alice
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m, _ = m.Update(enter)
+	if got := m.(TableModel).Selected(); !reflect.DeepEqual(got, table.Row{"carol", "Owner"}) {
+		t.Errorf("Selected() = %v, want carol", got)
+	}
+
+	// Esc clears the filter before leaving the table
+	m = NewTable("", columns, rows)
+	m = typeText(m, "/zzz")
+	if got := names(m); len(got) != 0 {
+		t.Errorf("rows = %v, want none", got)
+	}
+	m, _ = m.Update(enter)
+	if m, _ = m.Update(enter); m.(TableModel).Done() {
+		t.Error("enter with no matches ended the selection")
+	}
+	m, _ = m.Update(esc)
+	if got := names(m); len(got) != 3 || m.(TableModel).Cancelled() {
+		t.Errorf("after esc rows = %v, cancelled = %v; want all rows restored", got, m.(TableModel).Cancelled())
+	}
+	m, _ = m.Update(esc)
+	if !m.(TableModel).Cancelled() {
+		t.Error("second esc did not cancel")
+	}
+}
@@ -1,7 +1,9 @@
 package components
 
 import (
+	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
@@ -182,26 +184,7 @@ func (m TextareaModel) View() string {
 	s.WriteString("\n")
 
 	// Character count
-	charCount := len(m.textarea.Value())
-	countStyle := tui.MutedStyle
-	if charCount > m.charLimit*9/10 {
-		countStyle = tui.WarningStyle
-	}
-	if charCount >= m.charLimit {
-		countStyle = tui.ErrorStyle
-	}
-	s.WriteString(countStyle.Render(
-		strings.Repeat(" ", m.textarea.Width()-10) +
-			string(rune('0'+charCount/1000%10)) +
-			string(rune('0'+charCount/100%10)) +
-			string(rune('0'+charCount/10%10)) +
-			string(rune('0'+charCount%10)) +
-			"/" +
-			string(rune('0'+m.charLimit/1000%10)) +
-			string(rune('0'+m.charLimit/100%10)) +
-			string(rune('0'+m.charLimit/10%10)) +
-			string(rune('0'+m.charLimit%10)),
-	))
+	s.WriteString(charCountView(utf8.RuneCountInString(m.textarea.Value()), m.charLimit, m.textarea.Width()))
 	s.WriteString("\n\n")
 
 	// Help
@@ -214,6 +197,22 @@ func (m TextareaModel) View() string {
 	return s.String()
 }
 
+// charCountView renders "count/limit" right-aligned within width, colored as
+// the count approaches the limit.
+func charCountView(count, limit, width int) string {
+	style := tui.MutedStyle
+	if count > limit*9/10 {
+		style = tui.WarningStyle
+	}
+	if count >= limit {
+		style = tui.ErrorStyle
+	}
+
+	text := fmt.Sprintf("%d/%d", count, limit)
+	pad := max(width-lipgloss.Width(text), 0)
+	return strings.Repeat(" ", pad) + style.Render(text)
+}
+
 // Value returns the current textarea value.
 func (m TextareaModel) Value() string {
 	return m.textarea.Value()
@@ -0,0 +1,44 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestCharCountView(t *testing.T) {
+	tests := []struct {
+		name         string
+		count, limit int
+		width        int
+		wantText     string
+		wantWidth    int
+	}{
+		{name: "large limit", count: 0, limit: 50000, width: 20, wantText: "0/50000", wantWidth: 20},
+		{name: "four digits", count: 1234, limit: 9999, width: 20, wantText: "1234/9999", wantWidth: 20},
+		{name: "narrower than text", count: 12, limit: 50000, width: 3, wantText: "12/50000", wantWidth: 8},
+		{name: "negative width", count: 1, limit: 10, width: -5, wantText: "1/10", wantWidth: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := charCountView(tt.count, tt.limit, tt.width)
+			if !strings.Contains(got, tt.wantText) {
+				t.Errorf("charCountView() = %q, want it to contain %q", got, tt.wantText)
+			}
+			if w := lipgloss.Width(got); w != tt.wantWidth {
+				t.Errorf("charCountView() width = %d, want %d", w, tt.wantWidth)
+			}
+		})
+	}
+}
+
+func TestTextareaViewLargeLimit(t *testing.T) {
+	m := NewTextarea("Title", "Prompt", "").WithCharLimit(50000).WithSize(20, 3)
+
+	view := m.View()
+	if !strings.Contains(view, "0/50000") {
+		t.Errorf("View() missing char count 0/50000:\n%s", view)
+	}
+}
@@ -0,0 +1,117 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/morrisclay/scraps-cli/internal/tui"
+)
+
+// TypedConfirmModel is a destructive confirmation dialog that only confirms
+// once the user has typed the expected name.
+type TypedConfirmModel struct {
+	Title    string
+	Expected string
+
+	input     textinput.Model
+	confirmed bool
+	mismatch  bool // enter was pressed with the wrong name
+	done      bool
+}
+
+// NewTypedConfirm creates a typed confirmation dialog for expected.
+func NewTypedConfirm(title, expected string) TypedConfirmModel {
+	ti := textinput.New()
+	ti.Placeholder = expected
+	ti.CharLimit = 256
+	ti.Width = max(30, len(expected)+2)
+	ti.PromptStyle = tui.PromptStyle
+	ti.TextStyle = lipgloss.NewStyle()
+	ti.Focus()
+
+	return TypedConfirmModel{
+		Title:    title,
+		Expected: expected,
+		input:    ti,
+	}
+}
+
+// typedConfirmMatches reports whether input confirms expected. Surrounding
+// whitespace is ignored but the comparison is otherwise exact.
+func typedConfirmMatches(input, expected string) bool {
+	return expected != "" && strings.TrimSpace(input) == expected
+}
+
+// Init implements tea.Model.
+func (m TypedConfirmModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update implements tea.Model.
+func (m TypedConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			if !typedConfirmMatches(m.input.Value(), m.Expected) {
+				m.mismatch = true
+				return m, nil
+			}
+			m.confirmed = true
+			m.done = true
+			return m, tea.Quit
+		case "esc", "ctrl+c":
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.mismatch = false
+	return m, cmd
+}
+
+// View implements tea.Model.
+func (m TypedConfirmModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	var s string
+	s += tui.TitleStyle.Foreground(tui.ColorError).Render(m.Title) + "\n\n"
+	s += "This cannot be undone.\n"
+	s += fmt.Sprintf("Type %s to confirm:", lipgloss.NewStyle().Bold(true).Render(m.Expected)) + "\n\n"
+	s += m.input.View() + "\n\n"
+
+	if m.mismatch {
+		s += tui.ErrorStyle.Render("Name does not match") + "\n\n"
+	}
+
+	s += tui.HelpStyle.Render("enter confirm  esc cancel")
+
+	return tui.BoxStyle.Render(s)
+}
+
+// Confirmed returns whether the user typed the expected name.
+func (m TypedConfirmModel) Confirmed() bool {
+	return m.confirmed
+}
+
+// RunTypedConfirm asks the user to type expected to confirm a destructive
+// action and returns whether they did.
+func RunTypedConfirm(title, expected string) (bool, error) {
+	p := tea.NewProgram(NewTypedConfirm(title, expected))
+	finalModel, err := p.Run()
+	if err != nil {
+		return false, err
+	}
+
+	if m, ok := finalModel.(TypedConfirmModel); ok {
+		return m.Confirmed(), nil
+	}
+
+	return false, fmt.Errorf("unexpected model type")
+}
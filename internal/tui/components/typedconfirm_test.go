@@ -0,0 +1,59 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTypedConfirmMatches(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		want     bool
+	}{
+		{input: "mystore", expected: "mystore", want: true},
+		{input: "  mystore/repo \n", expected: "mystore/repo", want: true},
+		{input: "MyStore", expected: "mystore", want: false},
+		{input: "mystor", expected: "mystore", want: false},
+		{input: "", expected: "mystore", want: false},
+		{input: "", expected: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := typedConfirmMatches(tt.input, tt.expected); got != tt.want {
+			t.Errorf("typedConfirmMatches(%q, %q) = %v, want %v", tt.input, tt.expected, got, tt.want)
+		}
+	}
+}
+
+func TestTypedConfirmModel(t *testing.T) {
+	typeText := func(m tea.Model, s string) tea.Model {
+		for _, r := range s {
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		return m
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+
+	var m tea.Model = NewTypedConfirm("Delete Store", "prod")
+	m = typeText(m, "pro")
+	m, _ = m.Update(enter)
+	tc := m.(TypedConfirmModel)
+	if tc.done || tc.Confirmed() || !tc.mismatch {
+		t.Fatalf("after wrong name: done=%v confirmed=%v mismatch=%v, want false false true", tc.done, tc.Confirmed(), tc.mismatch)
+	}
+
+	m = typeText(m, "d")
+	m, _ = m.Update(enter)
+	if tc := m.(TypedConfirmModel); !tc.done || !tc.Confirmed() {
+		t.Errorf("after matching name: done=%v confirmed=%v, want true true", tc.done, tc.Confirmed())
+	}
+
+	m = NewTypedConfirm("Delete Store", "prod")
+	m = typeText(m, "prod")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if tc := m.(TypedConfirmModel); !tc.done || tc.Confirmed() {
+		t.Errorf("after esc: done=%v confirmed=%v, want true false", tc.done, tc.Confirmed())
+	}
+}
@@ -22,6 +22,17 @@ type WizardStep interface {
 	Value() any
 }
 
+// stepHelper is implemented by steps whose keybindings differ from the
+// default navigate/select footer.
+type stepHelper interface {
+	HelpText() string
+}
+
+// stepFilterer is implemented by steps that can filter their options.
+type stepFilterer interface {
+	Filtering() bool
+}
+
 // WizardModel is a multi-step wizard component.
 type WizardModel struct {
 	title       string
@@ -64,6 +75,12 @@ func (m WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		// Every step needs the size, not just the current one
+		for i, step := range m.steps {
+			if i != m.currentStep {
+				m.steps[i], _ = step.Update(msg)
+			}
+		}
 
 	case tea.KeyMsg:
 		switch {
@@ -73,6 +90,10 @@ func (m WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, func() tea.Msg { return WizardCancelledMsg{} }
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+			// Let a filtering step clear its filter first
+			if f, ok := m.steps[m.currentStep].(stepFilterer); ok && f.Filtering() {
+				break
+			}
 			if m.currentStep > 0 {
 				m.currentStep--
 				return m, m.steps[m.currentStep].Init()
@@ -138,6 +159,9 @@ func (m WizardModel) View() string {
 
 	// Help
 	helpText := "↑↓ navigate  enter select"
+	if h, ok := m.steps[m.currentStep].(stepHelper); ok {
+		helpText = h.HelpText()
+	}
 	if m.currentStep > 0 {
 		helpText += "  esc back"
 	}
@@ -236,15 +260,167 @@ func (s *TextInputStep) IsComplete() bool { return s.complete }
 // Value implements WizardStep.
 func (s *TextInputStep) Value() any { return s.value }
 
+// --- Option Filter ---
+
+// optionFilter adds "/"-to-filter to the select steps, mirroring
+// SearchListModel's filterItems.
+type optionFilter struct {
+	input  textinput.Model
+	active bool
+}
+
+func newOptionFilter() optionFilter {
+	ti := textinput.New()
+	ti.Placeholder = "Type to filter..."
+	ti.CharLimit = 100
+	ti.Width = 30
+	ti.PromptStyle = tui.PromptStyle
+	ti.TextStyle = lipgloss.NewStyle()
+	return optionFilter{input: ti}
+}
+
+// Filtering reports whether the filter is taking input or narrowing the
+// options, in which case esc belongs to the filter rather than the wizard.
+func (f *optionFilter) Filtering() bool {
+	return f.active || f.input.Value() != ""
+}
+
+// handleKey processes filter keys. It reports whether the key was consumed
+// and whether the query changed.
+func (f *optionFilter) handleKey(msg tea.KeyMsg) (handled, changed bool, cmd tea.Cmd) {
+	if !f.active {
+		switch msg.String() {
+		case "/":
+			f.active = true
+			f.input.Focus()
+			return true, false, textinput.Blink
+		case "esc":
+			if f.input.Value() != "" {
+				f.input.SetValue("")
+				return true, true, nil
+			}
+		}
+		return false, false, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		f.active = false
+		f.input.Blur()
+		changed = f.input.Value() != ""
+		f.input.SetValue("")
+		return true, changed, nil
+	case "enter":
+		f.active = false
+		f.input.Blur()
+		return true, false, nil
+	case "up", "down":
+		// Arrow navigation keeps working on the filtered subset
+		return false, false, nil
+	}
+
+	before := f.input.Value()
+	f.input, cmd = f.input.Update(msg)
+	return true, f.input.Value() != before, cmd
+}
+
+// matches returns the indices of the labels that contain the query.
+func (f *optionFilter) matches(labels []string) []int {
+	query := strings.ToLower(f.input.Value())
+	visible := make([]int, 0, len(labels))
+	for i, label := range labels {
+		if query == "" || strings.Contains(strings.ToLower(label), query) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// view renders the filter line, if filtering.
+func (f *optionFilter) view() string {
+	if !f.Filtering() {
+		return ""
+	}
+	return "Filter: " + f.input.View() + "\n\n"
+}
+
+// moveSelection moves selected by delta within visible and returns the new
+// selection. If selected is not visible, the first visible option is used.
+func moveSelection(visible []int, selected, delta int) int {
+	if len(visible) == 0 {
+		return -1
+	}
+	pos := -1
+	for i, idx := range visible {
+		if idx == selected {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return visible[0]
+	}
+	pos += delta
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(visible) {
+		pos = len(visible) - 1
+	}
+	return visible[pos]
+}
+
+// selectChromeHeight is the number of terminal lines the wizard frame,
+// prompt, filter and help take up around a select step's options.
+const selectChromeHeight = 16
+
+// maxVisibleOptions returns how many options fit in a terminal of the given
+// height, or 0 (no limit) if the height is unknown.
+func maxVisibleOptions(height int) int {
+	if height <= 0 {
+		return 0
+	}
+	return max(height-selectChromeHeight, 3)
+}
+
+// visibleWindow returns the [start, end) range of n options to render so
+// that pos stays on screen, scrolling as little as possible from offset.
+func visibleWindow(n, pos, offset, size int) (int, int) {
+	if size <= 0 || n <= size {
+		return 0, n
+	}
+	if pos >= 0 && pos < offset {
+		offset = pos
+	}
+	if pos >= offset+size {
+		offset = pos - size + 1
+	}
+	offset = max(0, min(offset, n-size))
+	return offset, offset + size
+}
+
+// indexOf returns the position of idx in visible, or -1.
+func indexOf(visible []int, idx int) int {
+	for i, v := range visible {
+		if v == idx {
+			return i
+		}
+	}
+	return -1
+}
+
 // --- Select Step ---
 
 // SelectStep is a wizard step with a selection list.
 type SelectStep struct {
-	title    string
-	prompt   string
-	options  []string
-	selected int
-	complete bool
+	title      string
+	prompt     string
+	options    []string
+	selected   int
+	filter     optionFilter
+	offset     int // first option shown when the list is windowed
+	maxVisible int // 0 means show every option
+	complete   bool
 }
 
 // NewSelectStep creates a new selection step.
@@ -253,6 +429,7 @@ func NewSelectStep(title, prompt string, options []string) *SelectStep {
 		title:   title,
 		prompt:  prompt,
 		options: options,
+		filter:  newOptionFilter(),
 	}
 }
 
@@ -265,18 +442,31 @@ func (s *SelectStep) Init() tea.Cmd { return nil }
 // Update implements WizardStep.
 func (s *SelectStep) Update(msg tea.Msg) (WizardStep, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.SetHeight(msg.Height)
+
 	case tea.KeyMsg:
+		if handled, changed, cmd := s.filter.handleKey(msg); handled {
+			if changed {
+				s.selected = moveSelection(s.filter.matches(s.options), -1, 0)
+			}
+			return s, cmd
+		}
+
+		visible := s.filter.matches(s.options)
 		switch msg.String() {
 		case "up", "k":
-			if s.selected > 0 {
-				s.selected--
-			}
+			s.selected = moveSelection(visible, s.selected, -1)
 		case "down", "j":
-			if s.selected < len(s.options)-1 {
-				s.selected++
-			}
+			s.selected = moveSelection(visible, s.selected, 1)
+		case "pgup":
+			s.selected = moveSelection(visible, s.selected, -max(s.maxVisible, 1))
+		case "pgdown":
+			s.selected = moveSelection(visible, s.selected, max(s.maxVisible, 1))
 		case "enter":
-			s.complete = true
+			if s.selected >= 0 && s.selected < len(s.options) {
+				s.complete = true
+			}
 			return s, nil
 		}
 	}
@@ -288,8 +478,21 @@ func (s *SelectStep) View() string {
 	var b strings.Builder
 	b.WriteString(s.prompt)
 	b.WriteString("\n\n")
+	b.WriteString(s.filter.view())
 
-	for i, opt := range s.options {
+	visible := s.filter.matches(s.options)
+	if len(visible) == 0 {
+		b.WriteString(tui.MutedStyle.Render("  No matches"))
+		b.WriteString("\n")
+	}
+
+	start, end := visibleWindow(len(visible), indexOf(visible, s.selected), s.offset, s.maxVisible)
+	s.offset = start
+	if start > 0 {
+		b.WriteString(tui.MutedStyle.Render("  ▲ more") + "\n")
+	}
+	for _, i := range visible[start:end] {
+		opt := s.options[i]
 		if i == s.selected {
 			b.WriteString(tui.SelectedStyle.Render("> " + opt))
 		} else {
@@ -297,10 +500,27 @@ func (s *SelectStep) View() string {
 		}
 		b.WriteString("\n")
 	}
+	if end < len(visible) {
+		b.WriteString(tui.MutedStyle.Render("  ▼ more") + "\n")
+	}
 
 	return b.String()
 }
 
+// SetHeight limits the number of options shown to fit a terminal of the
+// given height.
+func (s *SelectStep) SetHeight(height int) {
+	s.maxVisible = maxVisibleOptions(height)
+}
+
+// HelpText returns the footer keybindings for this step.
+func (s *SelectStep) HelpText() string {
+	return "↑↓ navigate  / filter  enter select"
+}
+
+// Filtering reports whether a filter is being typed or applied.
+func (s *SelectStep) Filtering() bool { return s.filter.Filtering() }
+
 // IsComplete implements WizardStep.
 func (s *SelectStep) IsComplete() bool { return s.complete }
 
@@ -315,23 +535,129 @@ func (s *SelectStep) Value() any {
 // SelectedIndex returns the selected index.
 func (s *SelectStep) SelectedIndex() int { return s.selected }
 
-// --- Item Select Step (with values) ---
+// --- Multi Select Step ---
 
-// ItemSelectStep is a wizard step with items that have associated values.
-type ItemSelectStep struct {
+// MultiSelectStep is a wizard step where several options can be chosen.
+// Space toggles the option under the cursor and enter confirms.
+type MultiSelectStep struct {
 	title    string
 	prompt   string
-	items    []SearchListItem
-	selected int
+	options  []string
+	checked  []bool
+	cursor   int
 	complete bool
 }
 
+// NewMultiSelectStep creates a new multi-selection step.
+func NewMultiSelectStep(title, prompt string, options []string) *MultiSelectStep {
+	return &MultiSelectStep{
+		title:   title,
+		prompt:  prompt,
+		options: options,
+		checked: make([]bool, len(options)),
+	}
+}
+
+// Title implements WizardStep.
+func (s *MultiSelectStep) Title() string { return s.title }
+
+// Init implements WizardStep.
+func (s *MultiSelectStep) Init() tea.Cmd { return nil }
+
+// Update implements WizardStep.
+func (s *MultiSelectStep) Update(msg tea.Msg) (WizardStep, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if s.cursor > 0 {
+				s.cursor--
+			}
+		case "down", "j":
+			if s.cursor < len(s.options)-1 {
+				s.cursor++
+			}
+		case " ":
+			if s.cursor < len(s.checked) {
+				s.checked[s.cursor] = !s.checked[s.cursor]
+			}
+		case "enter":
+			s.complete = true
+			return s, nil
+		}
+	}
+	return s, nil
+}
+
+// View implements WizardStep.
+func (s *MultiSelectStep) View() string {
+	var b strings.Builder
+	b.WriteString(s.prompt)
+	b.WriteString("\n\n")
+
+	for i, opt := range s.options {
+		box := "[ ] "
+		if s.checked[i] {
+			box = "[x] "
+		}
+		if i == s.cursor {
+			b.WriteString(tui.SelectedStyle.Render("> " + box + opt))
+		} else if s.checked[i] {
+			b.WriteString("  " + box + opt)
+		} else {
+			b.WriteString(tui.MutedStyle.Render("  " + box + opt))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// HelpText returns the footer keybindings for this step.
+func (s *MultiSelectStep) HelpText() string {
+	return "↑↓ navigate  space toggle  enter confirm"
+}
+
+// IsComplete implements WizardStep.
+func (s *MultiSelectStep) IsComplete() bool { return s.complete }
+
+// Value implements WizardStep. It returns the checked options as []string.
+func (s *MultiSelectStep) Value() any {
+	return s.Selected()
+}
+
+// Selected returns the checked options in display order.
+func (s *MultiSelectStep) Selected() []string {
+	selected := []string{}
+	for i, opt := range s.options {
+		if s.checked[i] {
+			selected = append(selected, opt)
+		}
+	}
+	return selected
+}
+
+// --- Item Select Step (with values) ---
+
+// ItemSelectStep is a wizard step with items that have associated values.
+type ItemSelectStep struct {
+	title      string
+	prompt     string
+	items      []SearchListItem
+	selected   int
+	filter     optionFilter
+	offset     int // first item shown when the list is windowed
+	maxVisible int // 0 means show every item
+	complete   bool
+}
+
 // NewItemSelectStep creates a new item selection step.
 func NewItemSelectStep(title, prompt string, items []SearchListItem) *ItemSelectStep {
 	return &ItemSelectStep{
 		title:  title,
 		prompt: prompt,
 		items:  items,
+		filter: newOptionFilter(),
 	}
 }
 
@@ -341,21 +667,43 @@ func (s *ItemSelectStep) Title() string { return s.title }
 // Init implements WizardStep.
 func (s *ItemSelectStep) Init() tea.Cmd { return nil }
 
+// filterLabels returns the text each item is filtered on.
+func (s *ItemSelectStep) filterLabels() []string {
+	labels := make([]string, len(s.items))
+	for i, item := range s.items {
+		labels[i] = item.FilterValue()
+	}
+	return labels
+}
+
 // Update implements WizardStep.
 func (s *ItemSelectStep) Update(msg tea.Msg) (WizardStep, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.SetHeight(msg.Height)
+
 	case tea.KeyMsg:
+		if handled, changed, cmd := s.filter.handleKey(msg); handled {
+			if changed {
+				s.selected = moveSelection(s.filter.matches(s.filterLabels()), -1, 0)
+			}
+			return s, cmd
+		}
+
+		visible := s.filter.matches(s.filterLabels())
 		switch msg.String() {
 		case "up", "k":
-			if s.selected > 0 {
-				s.selected--
-			}
+			s.selected = moveSelection(visible, s.selected, -1)
 		case "down", "j":
-			if s.selected < len(s.items)-1 {
-				s.selected++
-			}
+			s.selected = moveSelection(visible, s.selected, 1)
+		case "pgup":
+			s.selected = moveSelection(visible, s.selected, -max(s.maxVisible, 1))
+		case "pgdown":
+			s.selected = moveSelection(visible, s.selected, max(s.maxVisible, 1))
 		case "enter":
-			s.complete = true
+			if s.selected >= 0 && s.selected < len(s.items) {
+				s.complete = true
+			}
 			return s, nil
 		}
 	}
@@ -367,8 +715,20 @@ func (s *ItemSelectStep) View() string {
 	var b strings.Builder
 	b.WriteString(s.prompt)
 	b.WriteString("\n\n")
+	b.WriteString(s.filter.view())
 
-	for i, item := range s.items {
+	visible := s.filter.matches(s.filterLabels())
+	if len(visible) == 0 {
+		b.WriteString(tui.MutedStyle.Render("  No matches") + "\n")
+	}
+
+	start, end := visibleWindow(len(visible), indexOf(visible, s.selected), s.offset, s.maxVisible)
+	s.offset = start
+	if start > 0 {
+		b.WriteString(tui.MutedStyle.Render("  ▲ more") + "\n")
+	}
+	for _, i := range visible[start:end] {
+		item := s.items[i]
 		if i == s.selected {
 			b.WriteString(tui.SelectedStyle.Render("> "+item.Title()) + "\n")
 			if item.Description() != "" {
@@ -378,10 +738,30 @@ func (s *ItemSelectStep) View() string {
 			b.WriteString(tui.MutedStyle.Render("  "+item.Title()) + "\n")
 		}
 	}
+	if end < len(visible) {
+		b.WriteString(tui.MutedStyle.Render("  ▼ more") + "\n")
+	}
 
 	return b.String()
 }
 
+// SetHeight limits the number of items shown to fit a terminal of the given
+// height. One line is reserved for the selected item's description.
+func (s *ItemSelectStep) SetHeight(height int) {
+	s.maxVisible = maxVisibleOptions(height)
+	if s.maxVisible > 3 {
+		s.maxVisible--
+	}
+}
+
+// HelpText returns the footer keybindings for this step.
+func (s *ItemSelectStep) HelpText() string {
+	return "↑↓ navigate  / filter  enter select"
+}
+
+// Filtering reports whether a filter is being typed or applied.
+func (s *ItemSelectStep) Filtering() bool { return s.filter.Filtering() }
+
 // IsComplete implements WizardStep.
 func (s *ItemSelectStep) IsComplete() bool { return s.complete }
 
@@ -532,3 +912,51 @@ func (s *TextareaStep) IsComplete() bool { return s.complete }
 
 // Value implements WizardStep.
 func (s *TextareaStep) Value() any { return s.value }
+
+// --- Runner ---
+
+// wizardRunner wraps a WizardModel and quits once it completes or is cancelled.
+type wizardRunner struct {
+	wizard WizardModel
+}
+
+// Init implements tea.Model.
+func (r wizardRunner) Init() tea.Cmd {
+	return r.wizard.Init()
+}
+
+// Update implements tea.Model.
+func (r wizardRunner) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case WizardCompleteMsg, WizardCancelledMsg:
+		return r, tea.Quit
+	}
+
+	m, cmd := r.wizard.Update(msg)
+	r.wizard = m.(WizardModel)
+	return r, cmd
+}
+
+// View implements tea.Model.
+func (r wizardRunner) View() string {
+	return r.wizard.View()
+}
+
+// RunWizard runs a wizard and returns the step values, or nil if cancelled.
+func RunWizard(title string, steps []WizardStep) ([]any, error) {
+	p := tea.NewProgram(wizardRunner{wizard: NewWizard(title, steps)})
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := finalModel.(wizardRunner); ok {
+		if r.wizard.Cancelled() || !r.wizard.Done() {
+			return nil, nil
+		}
+		return r.wizard.Values(), nil
+	}
+
+	return nil, nil
+}
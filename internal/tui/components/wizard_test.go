@@ -0,0 +1,66 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestVisibleWindow(t *testing.T) {
+	tests := []struct {
+		name                 string
+		n, pos, offset, size int
+		wantStart, wantEnd   int
+	}{
+		{name: "fits", n: 5, pos: 4, size: 10, wantStart: 0, wantEnd: 5},
+		{name: "no limit", n: 50, pos: 40, size: 0, wantStart: 0, wantEnd: 50},
+		{name: "cursor in window", n: 50, pos: 3, offset: 0, size: 10, wantStart: 0, wantEnd: 10},
+		{name: "scroll down", n: 50, pos: 12, offset: 0, size: 10, wantStart: 3, wantEnd: 13},
+		{name: "scroll up", n: 50, pos: 5, offset: 20, size: 10, wantStart: 5, wantEnd: 15},
+		{name: "clamp to end", n: 50, pos: 49, offset: 45, size: 10, wantStart: 40, wantEnd: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := visibleWindow(tt.n, tt.pos, tt.offset, tt.size)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("visibleWindow() = %d, %d, want %d, %d", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestSelectStepWindowing(t *testing.T) {
+	options := make([]string, 100)
+	for i := range options {
+		options[i] = fmt.Sprintf("option-%03d", i)
+	}
+
+	step := NewSelectStep("Pick", "Pick one:", options)
+	step.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	view := step.View()
+	if strings.Contains(view, "▲ more") || !strings.Contains(view, "▼ more") {
+		t.Errorf("initial view should only show the ▼ indicator:\n%s", view)
+	}
+	if strings.Contains(view, "option-099") {
+		t.Errorf("initial view should not render options past the window:\n%s", view)
+	}
+
+	for i := 0; i < 50; i++ {
+		step.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+
+	view = step.View()
+	if !strings.Contains(view, "> option-050") {
+		t.Errorf("selected option should stay visible:\n%s", view)
+	}
+	if !strings.Contains(view, "▲ more") || !strings.Contains(view, "▼ more") {
+		t.Errorf("middle of list should show both indicators:\n%s", view)
+	}
+	if lines := strings.Count(view, "\n"); lines > 24 {
+		t.Errorf("view has %d lines, want it to fit a 24 line terminal", lines)
+	}
+}
@@ -3,45 +3,121 @@ package ws
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+// defaultPingInterval is how often the client pings the server to check
+// the connection is alive.
+const defaultPingInterval = 30 * time.Second
+
 // Client is a WebSocket client.
 type Client struct {
-	conn      *websocket.Conn
-	url       string
-	OnMessage func([]byte)
-	OnError   func(error)
-	OnClose   func()
-	done      chan struct{}
+	conn         *websocket.Conn
+	url          string
+	apiKey       string
+	OnMessage    func([]byte)
+	OnError      func(error)
+	OnClose      func()
+	done         chan struct{}
+	pingInterval time.Duration
 }
 
-// NewClient creates a new WebSocket client.
-func NewClient(url string) *Client {
+// NewClient creates a new WebSocket client that authenticates with apiKey.
+func NewClient(url, apiKey string) *Client {
 	return &Client{
-		url:  url,
-		done: make(chan struct{}),
+		url:          url,
+		apiKey:       apiKey,
+		done:         make(chan struct{}),
+		pingInterval: defaultPingInterval,
 	}
 }
 
-// Connect establishes the WebSocket connection.
+// WithPingInterval sets how often the server is pinged. If neither a pong
+// nor a message arrives for two intervals the connection is treated as
+// dead: OnError is called and Done is closed.
+func (c *Client) WithPingInterval(d time.Duration) *Client {
+	c.pingInterval = d
+	return c
+}
+
+// Connect establishes the WebSocket connection. The API key is sent in the
+// Authorization header, as stream.Client does. Servers that reject that are
+// retried with the key in a token query parameter, which proxies may log.
 func (c *Client) Connect() error {
 	dialer := websocket.Dialer{
 		HandshakeTimeout: 10 * time.Second,
 	}
 
-	conn, _, err := dialer.Dial(c.url, nil)
+	header := http.Header{}
+	if c.apiKey != "" {
+		header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	conn, resp, err := dialer.Dial(c.url, header)
+	if errors.Is(err, websocket.ErrBadHandshake) && resp != nil && resp.StatusCode == http.StatusUnauthorized && c.apiKey != "" {
+		tokenURL, urlErr := withToken(c.url, c.apiKey)
+		if urlErr != nil {
+			return urlErr
+		}
+		conn, _, err = dialer.Dial(tokenURL, header)
+	}
 	if err != nil {
 		return err
 	}
 
 	c.conn = conn
+	c.extendReadDeadline()
+	conn.SetPongHandler(func(string) error {
+		c.extendReadDeadline()
+		return nil
+	})
 	go c.readLoop()
+	go c.keepalive()
 	return nil
 }
 
+// extendReadDeadline gives the server two ping intervals to show it is
+// still there.
+func (c *Client) extendReadDeadline() {
+	c.conn.SetReadDeadline(time.Now().Add(2 * c.pingInterval))
+}
+
+// keepalive pings the server until the connection closes. A dead connection
+// makes the read loop time out rather than hang.
+func (c *Client) keepalive() {
+	ticker := time.NewTicker(c.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			// A failed ping is reported by the read loop when its deadline passes
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.pingInterval)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// withToken adds the API key to rawURL as the token query parameter.
+func withToken(rawURL, apiKey string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("token", apiKey)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
 // readLoop reads messages from the WebSocket.
 func (c *Client) readLoop() {
 	defer func() {
@@ -60,6 +136,7 @@ func (c *Client) readLoop() {
 			return
 		}
 
+		c.extendReadDeadline()
 		if c.OnMessage != nil {
 			c.OnMessage(message)
 		}
@@ -0,0 +1,152 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsURL converts an httptest server URL to a WebSocket URL.
+func wsURL(server *httptest.Server, path string) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + path
+}
+
+func TestConnectSendsAuthorizationHeader(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	gotAuth := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth <- r.Header.Get("Authorization")
+		if token := r.URL.Query().Get("token"); token != "" {
+			t.Errorf("token %q sent in the query string", token)
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client := NewClient(wsURL(server, "/stores/s/repos/r/ws"), "secret-key")
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	if auth := <-gotAuth; auth != "Bearer secret-key" {
+		t.Errorf("Authorization = %q, want Bearer secret-key", auth)
+	}
+}
+
+func TestConnectFallsBackToQueryToken(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var attempts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts = append(attempts, r.URL.RawQuery)
+		// This server only accepts the query parameter
+		if r.URL.Query().Get("token") != "secret-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"commit"}`))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	client := NewClient(wsURL(server, "/stores/s/repos/r/ws?branch=main"), "secret-key")
+	received := make(chan string, 1)
+	client.OnMessage = func(data []byte) { received <- string(data) }
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	select {
+	case msg := <-received:
+		if msg != `{"type":"commit"}` {
+			t.Errorf("message = %q", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no message after falling back to the query token")
+	}
+	if len(attempts) != 2 || attempts[0] != "branch=main" || !strings.Contains(attempts[1], "branch=main") {
+		t.Errorf("attempts = %q, want a header-only dial then one with the token", attempts)
+	}
+}
+
+func TestKeepaliveDetectsDeadConnection(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	release := make(chan struct{})
+	defer close(release)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Never read, so pings go unanswered, as with a dead peer
+		<-release
+	}))
+	defer server.Close()
+
+	client := NewClient(wsURL(server, "/ws"), "key").WithPingInterval(20 * time.Millisecond)
+	errs := make(chan error, 1)
+	client.OnError = func(err error) { errs <- err }
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	select {
+	case <-errs:
+	case <-time.After(time.Second):
+		t.Fatal("OnError not called after pongs stopped")
+	}
+	select {
+	case <-client.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after the connection died")
+	}
+}
+
+func TestKeepaliveKeepsLiveConnection(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// Reading answers pings with pongs
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(wsURL(server, "/ws"), "key").WithPingInterval(20 * time.Millisecond)
+	errs := make(chan error, 1)
+	client.OnError = func(err error) { errs <- err }
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Close()
+
+	select {
+	case err := <-errs:
+		t.Fatalf("OnError(%v) on a live connection", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -221,11 +221,11 @@ func TestStatus(t *testing.T) {
 }
 
 func TestLoginWithKey(t *testing.T) {
-	stdout, stderr, err := runScraps(t, "login", "--key", testAPIKey)
+	_, stderr, err := runScraps(t, "login", "--key", testAPIKey)
 	if err != nil {
 		t.Fatalf("Login failed: %v\nstderr: %s", err, stderr)
 	}
-	assertContains(t, stdout, "Logged in")
+	assertContains(t, stderr, "Logged in")
 }
 
 // ==================== Config Tests ====================
@@ -303,7 +303,7 @@ func TestRepoCreateShowDelete(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Repo create failed: %v\nstderr: %s\nstdout: %s", err, stderr, stdout)
 	}
-	assertContains(t, stdout, "created")
+	assertContains(t, stderr, "created")
 	createdRepo = true
 	t.Logf("Created repo: %s", repoRef)
 
@@ -426,7 +426,7 @@ func TestTokenCreateAndRevoke(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Token revoke failed: %v\nstderr: %s", err, stderr)
 	}
-	assertContains(t, stdout, "revoked")
+	assertContains(t, stderr, "revoked")
 }
 
 // ==================== Watch Tests ====================
@@ -591,14 +591,14 @@ func TestClaimAndRelease(t *testing.T) {
 		t.Logf("Claim (may not be supported on empty repo): %v\nstderr: %s\nstdout: %s", err, stderr, stdout)
 		return
 	}
-	assertContains(t, stdout, "Claimed")
+	assertContains(t, stderr, "Claimed")
 
 	// Release the pattern
 	stdout, stderr, err = runScraps(t, "release", repoRef, "*.go", "--agent-id", agentID)
 	if err != nil {
 		t.Fatalf("Release failed: %v\nstderr: %s", err, stderr)
 	}
-	assertContains(t, stdout, "Released")
+	assertContains(t, stderr, "Released")
 }
 
 // ==================== Error Handling Tests ====================
@@ -638,13 +638,42 @@ func TestUnauthorizedError(t *testing.T) {
 	}
 }
 
-func TestReleaseWithoutAgentID(t *testing.T) {
+func TestClaimReleasePersistedAgentID(t *testing.T) {
 	repoRef := fmt.Sprintf("%s/%s:%s", testStore, testRepo, testBranch)
-	_, stderr, err := runScraps(t, "release", repoRef, "*.go")
-	if err == nil {
-		t.Fatal("Expected error for release without --agent-id")
+	// A fresh config dir, so the agent ID is generated and saved by this test
+	env := []string{
+		"SCRAPS_API_KEY=" + testAPIKey,
+		"SCRAPS_HOST=" + testHost,
+		"SCRAPS_CONFIG_DIR=" + t.TempDir(),
+	}
+
+	stdout, stderr, err := runScrapsWithEnv(t, env, "claim", repoRef, "*.e2e", "--message", "E2E persisted agent claim")
+	if err != nil {
+		// Claim might fail if repo doesn't have the branch or coordination is not supported
+		t.Logf("Claim (may not be supported on empty repo): %v\nstderr: %s\nstdout: %s", err, stderr, stdout)
+		return
+	}
+	_, claimedAs, ok := strings.Cut(firstLineContaining(stderr, "Claimed patterns as "), "Claimed patterns as ")
+	if !ok || claimedAs == "" {
+		t.Fatalf("Claim did not report its agent ID: %s", stderr)
+	}
+
+	_, stderr, err = runScrapsWithEnv(t, env, "release", repoRef, "*.e2e")
+	if err != nil {
+		t.Fatalf("Release without --agent-id failed: %v\nstderr: %s", err, stderr)
+	}
+	assertContains(t, stderr, "Released patterns as "+claimedAs)
+}
+
+// firstLineContaining returns the first line of output containing s, with
+// surrounding whitespace removed, or "" if there is none.
+func firstLineContaining(output, s string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, s) {
+			return strings.TrimSpace(line)
+		}
 	}
-	assertContains(t, stderr, "agent-id")
+	return ""
 }
 
 // ==================== Cleanup Tests (run last) ====================
@@ -654,20 +683,20 @@ func TestZZ_Cleanup_RepoDelete(t *testing.T) {
 		t.Skip("No repo was created, skipping delete")
 	}
 	repoRef := fmt.Sprintf("%s/%s", testStore, testRepo)
-	stdout, stderr, err := runScraps(t, "repo", "delete", "--force", repoRef)
+	_, stderr, err := runScraps(t, "repo", "delete", "--force", repoRef)
 	if err != nil {
 		t.Fatalf("Repo delete failed: %v\nstderr: %s", err, stderr)
 	}
-	assertContains(t, stdout, "deleted")
+	assertContains(t, stderr, "deleted")
 	t.Logf("Deleted repo: %s", repoRef)
 }
 
 func TestZZ_Cleanup_Logout(t *testing.T) {
-	stdout, stderr, err := runScraps(t, "logout")
+	_, stderr, err := runScraps(t, "logout")
 	if err != nil {
 		t.Fatalf("Logout failed: %v\nstderr: %s", err, stderr)
 	}
-	assertContains(t, stdout, "Logged out")
+	assertContains(t, stderr, "Logged out")
 }
 
 // ==================== Key Reset Tests (can't fully test without email) ====================